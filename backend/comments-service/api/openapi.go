@@ -0,0 +1,10 @@
+// Package api embeds the OpenAPI description of the HTTP API so the
+// service can serve the document it was built with.
+package api
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3.1 document in YAML
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
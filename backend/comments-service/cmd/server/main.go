@@ -1,45 +1,315 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
-	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	appcomment "github.com/ayushvyasgit/comments-service/internal/application/comment"
+	applike "github.com/ayushvyasgit/comments-service/internal/application/like"
+	appmoderation "github.com/ayushvyasgit/comments-service/internal/application/moderation"
+	"github.com/ayushvyasgit/comments-service/internal/application/notification"
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/cache/memory"
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/cache/redis"
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/health"
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/logging"
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/messaging/rabbitmq"
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/persistence/postgres"
+	grpcapi "github.com/ayushvyasgit/comments-service/internal/interfaces/grpc"
+	httpapi "github.com/ayushvyasgit/comments-service/internal/interfaces/http"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/websocket"
+	"github.com/ayushvyasgit/comments-service/pkg/apikey"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
 )
 
+// configPollInterval is how often the config file is checked for changes
+const configPollInterval = 5 * time.Second
+
+// User preferences pick the language of error responses and emails, so the
+// most recently seen users are kept in memory for a short while
+const (
+	userCacheSize = 10000
+	userCacheTTL  = time.Minute
+)
+
+// settingsCacheSize bounds how many tenants' settings each replica keeps
+// decoded
+const settingsCacheSize = 10000
+
 func main() {
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+	// "config print" dumps the effective configuration and "config seal"
+	// encrypts a JSON object of secrets from stdin into a vault on stdout
+	args := os.Args[1:]
+	command := ""
+	if len(args) >= 2 && args[0] == "config" {
+		command, args = args[1], args[2:]
+	}
+
+	cfg, err := config.Load(args...)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal("Failed to load config: ", err)
+	}
+	switch command {
+	case "":
+	case "print":
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal("Failed to print config: ", err)
+		}
+		return
+	case "seal":
+		if err := sealVault(cfg.Secrets.VaultKeyFile, os.Stdin, os.Stdout); err != nil {
+			log.Fatal("Failed to seal vault: ", err)
+		}
+		return
+	default:
+		log.Fatalf("Unknown command: config %s", command)
 	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Reloadable settings are read from watcher.Current(), not cfg
+	watcher := config.NewWatcher(cfg, args...)
+	logging.Configure(cfg.App)
+	watcher.Subscribe(func(old, next *config.Config) {
+		logging.Configure(next.App)
+	})
+
+	secrets := config.NewSecrets(cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := postgres.Connect(ctx, cfg.DatabaseDSN(), cfg.Database, secret(secrets, "DB_PASSWORD"))
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
 	}
 
-	r := gin.Default()
+	rdb := redis.NewClient(cfg, secret(secrets, "REDIS_PASSWORD"))
+	broker := rabbitmq.NewClient(cfg.RabbitMQ.URL)
+	secrets.OnChange("RABBITMQ_URL", broker.SetURL)
+
+	// Clients are closed in reverse order once the server has drained
+	closers := []func(){db.Close, func() { rdb.Close() }, func() { broker.Close() }}
 
-	// Health check
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "comments-service",
+	checks := []health.Check{
+		{
+			Name:     "postgres",
+			Critical: true,
+			Probe: func(ctx context.Context) (any, error) {
+				if err := db.Ping(ctx); err != nil {
+					return nil, err
+				}
+				rows, err := postgres.DatabaseHealth(ctx, db)
+				if err != nil {
+					return nil, err
+				}
+				if err := postgres.Warnings(rows); err != nil {
+					return rows, health.Degraded(err)
+				}
+				return rows, nil
+			},
+		},
+		{
+			Name:     "redis",
+			Critical: true,
+			Probe: func(ctx context.Context) (any, error) {
+				return nil, redis.Ping(ctx, rdb)
+			},
+		},
+		{
+			// Events are best effort, so a broker outage only degrades the service
+			Name: "rabbitmq",
+			Probe: func(ctx context.Context) (any, error) {
+				return nil, broker.Ping(ctx)
+			},
+		},
+	}
+	if cfg.Database.ReadHost != "" {
+		replica, err := postgres.Connect(ctx, cfg.ReadDatabaseDSN(), cfg.Database, secret(secrets, "DB_PASSWORD"))
+		if err != nil {
+			log.Fatal("Failed to configure read replica:", err)
+		}
+		closers = append(closers, replica.Close)
+		checks = append(checks, health.Check{
+			Name: "postgres_replica",
+			Probe: func(ctx context.Context) (any, error) {
+				return nil, replica.Ping(ctx)
+			},
 		})
+	}
+	checker := health.NewChecker(cfg.Health.ProbeTimeout, checks...)
+
+	// Fans comment events out to this replica's streams until shutdown,
+	// which also ends every open stream so the server can drain
+	events := redis.NewCommentEvents(rdb, int64(cfg.Realtime.ReplayLimit), cfg.Realtime.ReplayTTL)
+	go func() {
+		if err := events.Run(ctx); err != nil {
+			log.Printf("Comment event fan-out stopped: %v", err)
+		}
+	}()
+
+	gateway := websocket.NewGateway(events, redis.NewPresence(rdb), cfg.Realtime, func() config.CORSConfig { return watcher.Current().CORS })
+
+	signer := cursor.NewSigner([]byte(cfg.Cursor.Secret))
+	secrets.OnChange("CURSOR_SECRET", func(key string) { signer.Rotate([]byte(key)) })
+	peppers, err := apikey.ParsePeppers(cfg.APIKeys.Peppers)
+	if err != nil {
+		log.Fatal("Failed to read API key peppers:", err)
+	}
+	hasher, err := apikey.NewHasher(peppers, cfg.APIKeys.AcceptLegacy)
+	if err != nil {
+		log.Fatal("Failed to configure API key hashing:", err)
+	}
+	// A rotated pepper list that does not parse keeps the current one
+	secrets.OnChange("API_KEY_PEPPERS", func(value string) {
+		peppers, err := apikey.ParsePeppers(value)
+		if err == nil {
+			err = hasher.Rotate(peppers, cfg.APIKeys.AcceptLegacy)
+		}
+		if err != nil {
+			log.Printf("Ignoring rotated API key peppers: %v", err)
+		}
 	})
+	if cfg.Secrets.RefreshInterval > 0 {
+		go secrets.Watch(ctx, cfg.Secrets.RefreshInterval)
+	}
+	go watcher.Run(ctx, configPollInterval)
 
-	r.GET("/", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "Comments Service API",
-			"version": "1.0.0",
-		})
+	tenants := postgres.NewTenantRepository(db)
+	auth := apptenant.NewAuthenticator(tenants, hasher)
+	settingsCache := apptenant.NewSettingsCache(settingsCacheSize, cfg.Tenants.SettingsCacheTTL)
+	invalidations := redis.NewSettingsInvalidations(rdb)
+	go func() {
+		if err := invalidations.Run(ctx, settingsCache.Invalidate); err != nil {
+			log.Printf("Tenant settings invalidation stopped: %v", err)
+		}
+	}()
+	users := memory.NewUsers(postgres.NewUserRepository(db), userCacheSize, userCacheTTL)
+	commentRepo := postgres.NewCommentRepository(db)
+	comments := appcomment.NewService(
+		commentRepo,
+		signer,
+		events,
+		notification.NewNotifier(notification.NewRenderer(i18n.Default(), users), broker),
+	)
+	likes := applike.NewService(postgres.NewLikeRepository(db), commentRepo, events)
+	// Shared so HTTP and gRPC calls spend the same quotas
+	rateLimits := redis.NewRateLimitCounter(rdb)
+
+	router := httpapi.NewRouter(httpapi.Dependencies{
+		Tenants:        auth,
+		Settings:       settingsCache,
+		Comments:       comments,
+		Likes:          likes,
+		TenantSettings: apptenant.NewSettingsService(tenants, settingsCache, invalidations),
+		Subdomains:     apptenant.NewSubdomainService(nil, tenants),
+		Health:         checker,
+		Events:         events,
+		Heartbeat:      cfg.Realtime.Heartbeat,
+		Gateway:        gateway,
+		MaxBodyBytes:   int64(cfg.Server.MaxBodyBytes),
+		CORS:           func() config.CORSConfig { return watcher.Current().CORS },
+		Features:       func() config.FeaturesConfig { return watcher.Current().Features },
+		Users:          users,
+		RateLimits:     rateLimits,
+		RateLimit:      func() config.RateLimitConfig { return watcher.Current().RateLimit },
+
+		Idempotency:        redis.NewIdempotencyStore(rdb),
+		IdempotencyTTL:     cfg.Idempotency.TTL,
+		IdempotencyLockTTL: cfg.Idempotency.LockTTL,
 	})
+	server := httpapi.NewServer(cfg, router)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		log.Printf("🚀 Server starting on %s\n", server.Addr())
+		serveErr <- server.Start()
+	}()
+
+	var grpcServer *grpcapi.Server
+	if cfg.Server.GRPCPort > 0 {
+		grpcServer = grpcapi.NewServer(cfg, grpcapi.Dependencies{
+			Tenants:    auth,
+			Settings:   settingsCache,
+			Comments:   comments,
+			Likes:      likes,
+			Moderation: appmoderation.NewService(postgres.NewModerationRepository(db)),
+			RateLimits: rateLimits,
+			RateLimit:  func() config.RateLimitConfig { return watcher.Current().RateLimit },
+		})
+		go func() {
+			log.Printf("gRPC server starting on %s\n", grpcServer.Addr())
+			serveErr <- grpcServer.Start()
+		}()
+	}
+
+	exitCode := 0
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			log.Printf("Server failed: %v", err)
+			exitCode = 1
+		}
+	case <-ctx.Done():
+		log.Println("Shutdown signal received, draining in-flight requests")
+	}
+	stop()
+	checker.SetDraining()
 
-	fmt.Printf("🚀 Server starting on port %s\n", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	gateway.Shutdown(shutdownCtx)
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("gRPC server forced to shutdown: %v", err)
+		}
+	}
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		closers[i]()
+	}
+	log.Println("Server exited")
+	os.Exit(exitCode)
+}
+
+// secret returns a getter for the current value of a rotatable secret
+func secret(secrets *config.Secrets, key string) func() string {
+	return func() string { return secrets.Get(key) }
+}
+
+// sealVault encrypts the JSON object of secrets read from in with the key in
+// keyFile and writes the vault to out
+func sealVault(keyFile string, in io.Reader, out io.Writer) error {
+	if keyFile == "" {
+		return errors.New("SECRETS_VAULT_KEY_FILE is not set")
+	}
+	key, err := config.ReadVaultKey(keyFile)
+	if err != nil {
+		return err
+	}
+	var values map[string]string
+	if err := json.NewDecoder(in).Decode(&values); err != nil {
+		return fmt.Errorf("decode secrets: %w", err)
+	}
+	sealed, err := config.SealVault(key, values)
+	if err != nil {
+		return err
 	}
-}
\ No newline at end of file
+	_, err = out.Write(sealed)
+	return err
+}
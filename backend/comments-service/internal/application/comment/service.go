@@ -0,0 +1,346 @@
+// Package comment implements the comment use cases on top of the domain
+// repository, translating domain failures into AppErrors.
+package comment
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/id"
+	"github.com/ayushvyasgit/comments-service/pkg/text"
+	"github.com/ayushvyasgit/comments-service/pkg/utils"
+)
+
+// CreateInput is the command for posting a comment or reply
+type CreateInput struct {
+	TenantID      string
+	EntityType    string
+	EntityID      string
+	ParentID      *string
+	AuthorID      string
+	AuthorName    string
+	AuthorEmail   *string
+	Content       string
+	ContentFormat comment.Format
+}
+
+// UpdateInput is the command for editing a comment's content
+type UpdateInput struct {
+	TenantID string
+	ID       string
+	Content  string
+	EditedBy string
+	Reason   *string
+}
+
+// ListInput selects a page of comments under an entity. Cursor is a token
+// from a previous ListResult; empty starts at the top.
+type ListInput struct {
+	TenantID   string
+	EntityType string
+	EntityID   string
+	ParentID   *string
+	Sort       comment.Sort
+	Cursor     string
+	Limit      int
+}
+
+// ListResult is a page of comments with the tokens of its neighbours. Next
+// and Prev are empty when there is nothing further in that direction.
+type ListResult struct {
+	Comments []*comment.Comment
+	Limit    int
+	Next     string
+	Prev     string
+}
+
+// ReplyNotifier tells the author of a comment about a reply to it
+type ReplyNotifier interface {
+	NotifyReply(ctx context.Context, parent, reply *comment.Comment) error
+}
+
+// Service exposes the comment use cases
+type Service struct {
+	repo     comment.Repository
+	cursors  *cursor.Signer
+	events   comment.EventPublisher
+	notifier ReplyNotifier
+}
+
+// NewService creates a comment service backed by repo. Pagination tokens
+// are signed with cursors and changes are announced on events; replies are
+// reported to the parent's author through notifier. Either may be nil when
+// it is not wired.
+func NewService(repo comment.Repository, cursors *cursor.Signer, events comment.EventPublisher, notifier ReplyNotifier) *Service {
+	return &Service{repo: repo, cursors: cursors, events: events, notifier: notifier}
+}
+
+// Create validates and stores a new comment, resolving its position in the
+// thread from the parent when one is given
+func (s *Service) Create(ctx context.Context, in CreateInput) (*comment.Comment, error) {
+	limits := settings.For(ctx)
+	if in.ContentFormat == "" {
+		in.ContentFormat = comment.FormatPlain
+	}
+	in.Content = text.Clean(in.Content)
+	in.AuthorName = text.Clean(in.AuthorName)
+
+	var v violations
+	v.required("entity_type", in.EntityType)
+	v.required("entity_id", in.EntityID)
+	v.required("author_id", in.AuthorID)
+	v.required("author_name", in.AuthorName)
+	v.content(in.Content, limits)
+	in.AuthorEmail = v.email("author_email", in.AuthorEmail, limits.EmailPolicy())
+	if !in.ContentFormat.IsValid() {
+		v.add("content_format", "oneof", map[string]any{"values": []string{"plain", "markdown", "html"}}, "must be one of plain, markdown, html")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	commentID, err := id.New()
+	if err != nil {
+		return nil, errors.InternalServer("failed to generate comment id", err)
+	}
+
+	c := &comment.Comment{
+		ID:            commentID.String(),
+		TenantID:      in.TenantID,
+		EntityType:    in.EntityType,
+		EntityID:      in.EntityID,
+		AuthorID:      in.AuthorID,
+		AuthorName:    in.AuthorName,
+		AuthorEmail:   in.AuthorEmail,
+		Content:       in.Content,
+		ContentFormat: in.ContentFormat,
+		Status:        comment.StatusActive,
+	}
+
+	var parent *comment.Comment
+	if in.ParentID != nil {
+		parent, err = s.repo.GetByID(ctx, in.TenantID, *in.ParentID)
+		if err != nil {
+			if stderrors.Is(err, comment.ErrNotFound) {
+				return nil, errors.NotFound("parent comment not found").WithMessageID("comment.parent_not_found", nil)
+			}
+			return nil, mapRepoError(err, "failed to load parent comment")
+		}
+		if parent.EntityType != in.EntityType || parent.EntityID != in.EntityID {
+			return nil, errors.BadRequest("parent comment belongs to a different entity").WithMessageID("comment.parent_other_entity", nil)
+		}
+		if max := min(comment.MaxDepth, limits.MaxNestingDepth); parent.Depth+1 > max {
+			return nil, errors.Validation(errors.FieldError{
+				Path:    "parent_id",
+				Rule:    "max_nesting_depth",
+				Params:  map[string]any{"max": max},
+				Message: "is nested too deeply",
+			})
+		}
+		c.ParentID = utils.StringPtr(parent.ID)
+		c.Depth = parent.Depth + 1
+		c.Path = parent.ChildPath()
+	}
+
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, mapRepoError(err, "failed to create comment")
+	}
+
+	e := comment.NewEvent(comment.EventCreated, c)
+	e.Comment = c
+	s.publish(ctx, e)
+	if parent != nil {
+		s.notify(ctx, parent, c)
+	}
+	return c, nil
+}
+
+// Get returns a single live comment
+func (s *Service) Get(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	c, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, mapRepoError(err, "failed to load comment")
+	}
+	return c, nil
+}
+
+// Update replaces a comment's content and records the revision
+func (s *Service) Update(ctx context.Context, in UpdateInput) (*comment.Comment, error) {
+	in.Content = text.Clean(in.Content)
+
+	var v violations
+	v.required("edited_by", in.EditedBy)
+	v.content(in.Content, settings.For(ctx))
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	c, err := s.repo.Update(ctx, in.TenantID, in.ID, comment.Edit{
+		Content:  in.Content,
+		EditedBy: in.EditedBy,
+		Reason:   in.Reason,
+	})
+	if err != nil {
+		return nil, mapRepoError(err, "failed to update comment")
+	}
+
+	e := comment.NewEvent(comment.EventEdited, c)
+	e.Comment = c
+	s.publish(ctx, e)
+	return c, nil
+}
+
+// Delete soft-deletes a comment together with all of its replies and
+// returns the number of comments removed
+func (s *Service) Delete(ctx context.Context, tenantID, id string) (int, error) {
+	// The entity is only needed to route the event
+	var target *comment.Comment
+	if s.events != nil {
+		c, err := s.repo.GetByID(ctx, tenantID, id)
+		if err != nil {
+			return 0, mapRepoError(err, "failed to load comment")
+		}
+		target = c
+	}
+
+	n, err := s.repo.SoftDeleteTree(ctx, tenantID, id)
+	if err != nil {
+		return 0, mapRepoError(err, "failed to delete comment")
+	}
+
+	if target != nil {
+		e := comment.NewEvent(comment.EventDeleted, target)
+		e.Deleted = n
+		s.publish(ctx, e)
+	}
+	return n, nil
+}
+
+// publish announces e to live subscribers. The change is already committed,
+// so a failure only costs subscribers an update and is logged.
+func (s *Service) publish(ctx context.Context, e comment.Event) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, e); err != nil {
+		log.Printf("failed to publish %s for comment %s: %v", e.Type, e.CommentID, err)
+	}
+}
+
+// notify tells the author of parent about reply. As with publish, the reply
+// is already committed, so a failure is only logged.
+func (s *Service) notify(ctx context.Context, parent, reply *comment.Comment) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.NotifyReply(ctx, parent, reply); err != nil {
+		log.Printf("failed to notify the author of comment %s about reply %s: %v", parent.ID, reply.ID, err)
+	}
+}
+
+// List returns a page of comments under an entity
+func (s *Service) List(ctx context.Context, in ListInput) (*ListResult, error) {
+	sort := sortOrDefault(in.Sort)
+	var v violations
+	v.required("entity_type", in.EntityType)
+	v.required("entity_id", in.EntityID)
+	v.sort(sort)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	q := comment.ListQuery{
+		TenantID:   in.TenantID,
+		EntityType: in.EntityType,
+		EntityID:   in.EntityID,
+		ParentID:   in.ParentID,
+		Sort:       sort,
+		Limit:      cursor.Limit(in.Limit),
+	}
+	if in.Cursor != "" {
+		after, err := s.decodeCursor(in.Cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+		q.After = after
+	}
+
+	comments, hasMore, err := s.page(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Comments: comments, Limit: q.Limit}
+	if len(comments) == 0 {
+		return result, nil
+	}
+
+	first := comments[0].CursorFor(sort)
+	last := comments[len(comments)-1].CursorFor(sort)
+	backward := q.After != nil && q.After.Backward
+	// Walking forward there is always something behind a cursor; walking
+	// backward there is always something ahead of it.
+	if (backward && hasMore) || (!backward && q.After != nil) {
+		if result.Prev, err = s.encodeCursor(first.Reverse()); err != nil {
+			return nil, err
+		}
+	}
+	if (!backward && hasMore) || backward {
+		if result.Next, err = s.encodeCursor(last); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// page reads one row past q.Limit to learn whether another page follows
+func (s *Service) page(ctx context.Context, q comment.ListQuery) ([]*comment.Comment, bool, error) {
+	limit := q.Limit
+	q.Limit++
+	comments, err := s.repo.List(ctx, q)
+	if err != nil {
+		return nil, false, mapRepoError(err, "failed to list comments")
+	}
+	if len(comments) <= limit {
+		return comments, false, nil
+	}
+	// The extra row sits on the far side of the page from the cursor
+	if q.After != nil && q.After.Backward {
+		return comments[1:], true, nil
+	}
+	return comments[:limit], true, nil
+}
+
+func (s *Service) encodeCursor(c cursor.Cursor) (string, error) {
+	token, err := s.cursors.Encode(c)
+	if err != nil {
+		return "", errors.InternalServer("failed to encode cursor", err)
+	}
+	return token, nil
+}
+
+func (s *Service) decodeCursor(token string, sort comment.Sort) (*cursor.Cursor, error) {
+	var c cursor.Cursor
+	if err := s.cursors.Decode(token, &c); err != nil || c.Order != string(sort) {
+		return nil, errors.BadRequest("invalid cursor").WithMessageID("comment.invalid_cursor", nil)
+	}
+	return &c, nil
+}
+
+// mapRepoError keeps the AppErrors repositories translate driver errors
+// into, such as a Conflict for a unique violation
+func mapRepoError(err error, message string) error {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	if stderrors.Is(err, comment.ErrNotFound) {
+		return errors.NotFound("comment not found").WithMessageID("comment.not_found", nil)
+	}
+	return errors.InternalServer(message, err)
+}
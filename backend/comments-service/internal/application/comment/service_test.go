@@ -0,0 +1,503 @@
+package comment
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+type fakeRepo struct {
+	comments map[string]*comment.Comment
+	seq      int
+	// createErr is returned by Create when set
+	createErr error
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{comments: map[string]*comment.Comment{}}
+}
+
+func (r *fakeRepo) Create(ctx context.Context, c *comment.Comment) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.seq++
+	c.ID = fmt.Sprintf("00000000-0000-0000-0000-%012d", r.seq)
+	r.comments[c.ID] = c
+	if c.ParentID != nil {
+		r.comments[*c.ParentID].ReplyCount++
+	}
+	return nil
+}
+
+func (r *fakeRepo) GetByID(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	c, ok := r.comments[id]
+	if !ok || c.TenantID != tenantID || c.Status == comment.StatusDeleted {
+		return nil, comment.ErrNotFound
+	}
+	return c, nil
+}
+
+func (r *fakeRepo) Update(ctx context.Context, tenantID, id string, edit comment.Edit) (*comment.Comment, error) {
+	c, err := r.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Content = edit.Content
+	c.IsEdited = true
+	return c, nil
+}
+
+func (r *fakeRepo) SoftDeleteTree(ctx context.Context, tenantID, id string) (int, error) {
+	root, err := r.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, c := range r.comments {
+		if c.ID == id || strings.HasPrefix(c.Path, root.ChildPath()) {
+			c.Status = comment.StatusDeleted
+			n++
+		}
+	}
+	return n, nil
+}
+
+// List pages through matching comments in creation order, treating the
+// cursor ID as the position
+func (r *fakeRepo) List(ctx context.Context, q comment.ListQuery) ([]*comment.Comment, error) {
+	var out []*comment.Comment
+	for _, c := range r.sorted() {
+		if c.TenantID != q.TenantID || c.EntityType != q.EntityType || c.EntityID != q.EntityID || c.Status != comment.StatusActive {
+			continue
+		}
+		if (q.ParentID == nil) != (c.ParentID == nil) || (q.ParentID != nil && *q.ParentID != *c.ParentID) {
+			continue
+		}
+		out = append(out, c)
+	}
+	if q.After == nil {
+		return out[:min(q.Limit, len(out))], nil
+	}
+	for i, c := range out {
+		if c.ID != q.After.ID {
+			continue
+		}
+		if q.After.Backward {
+			return out[max(0, i-q.Limit):i], nil
+		}
+		rest := out[i+1:]
+		return rest[:min(q.Limit, len(rest))], nil
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) Descendants(ctx context.Context, tenantID string, rootIDs []string, maxDepth, perParent int, sort comment.Sort) ([]*comment.Comment, error) {
+	var out []*comment.Comment
+	perParentSeen := map[string]int{}
+	for depth := 1; depth <= maxDepth; depth++ {
+		for _, c := range r.sorted() {
+			if c.Depth != depth || c.TenantID != tenantID || c.Status != comment.StatusActive {
+				continue
+			}
+			for _, id := range rootIDs {
+				root := r.comments[id]
+				if strings.HasPrefix(c.Path, root.ChildPath()) && perParentSeen[*c.ParentID] < perParent {
+					perParentSeen[*c.ParentID]++
+					out = append(out, c)
+					break
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) ReplyCounts(ctx context.Context, tenantID string, parentIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, c := range r.comments {
+		if c.ParentID != nil && c.TenantID == tenantID && c.Status == comment.StatusActive && slices.Contains(parentIDs, *c.ParentID) {
+			counts[*c.ParentID]++
+		}
+	}
+	return counts, nil
+}
+
+// sorted returns comments in creation order
+func (r *fakeRepo) sorted() []*comment.Comment {
+	out := make([]*comment.Comment, 0, len(r.comments))
+	for i := 1; i <= r.seq; i++ {
+		if c, ok := r.comments[fmt.Sprintf("00000000-0000-0000-0000-%012d", i)]; ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func newTestService(repo comment.Repository) *Service {
+	return NewService(repo, cursor.NewSigner([]byte("test")), nil, nil)
+}
+
+type fakePublisher struct {
+	events []comment.Event
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, e comment.Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+type fakeNotifier struct {
+	replies map[string]string
+}
+
+func (n *fakeNotifier) NotifyReply(ctx context.Context, parent, reply *comment.Comment) error {
+	n.replies[reply.ID] = parent.ID
+	return nil
+}
+
+func validCreate() CreateInput {
+	return CreateInput{
+		TenantID:   "tenant-1",
+		EntityType: "post",
+		EntityID:   "post_1",
+		AuthorID:   "author-1",
+		AuthorName: "Ada",
+		Content:    "Hello",
+	}
+}
+
+func assertAppError(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) {
+		t.Fatalf("expected AppError, got %v", err)
+	}
+	if appErr.StatusCode != status {
+		t.Errorf("expected status %d, got %d", status, appErr.StatusCode)
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+
+	root, err := svc.Create(context.Background(), validCreate())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.Depth != 0 || root.Path != "" || root.ParentID != nil {
+		t.Errorf("expected root comment, got depth=%d path=%q", root.Depth, root.Path)
+	}
+	if root.ContentFormat != comment.FormatPlain {
+		t.Errorf("expected default format plain, got %s", root.ContentFormat)
+	}
+
+	in := validCreate()
+	in.ParentID = &root.ID
+	reply, err := svc.Create(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply.Depth != 1 {
+		t.Errorf("expected depth 1, got %d", reply.Depth)
+	}
+	if reply.Path != "."+root.ID {
+		t.Errorf("expected path .%s, got %s", root.ID, reply.Path)
+	}
+	if root.ReplyCount != 1 {
+		t.Errorf("expected parent reply count 1, got %d", root.ReplyCount)
+	}
+}
+
+func TestService_CreateKeepsTranslatedRepositoryErrors(t *testing.T) {
+	repo := newFakeRepo()
+	svc := newTestService(repo)
+
+	repo.createErr = errors.Aborted("request conflicted with a concurrent update, please retry", nil)
+	_, err := svc.Create(context.Background(), validCreate())
+	assertAppError(t, err, http.StatusConflict)
+
+	repo.createErr = stderrors.New("conn closed")
+	_, err = svc.Create(context.Background(), validCreate())
+	assertAppError(t, err, http.StatusInternalServerError)
+}
+
+func TestService_CreateValidation(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	missingParent := "00000000-0000-0000-0000-999999999999"
+
+	tests := []struct {
+		name   string
+		modify func(*CreateInput)
+		status int
+	}{
+		{"empty content", func(in *CreateInput) { in.Content = "  " }, http.StatusUnprocessableEntity},
+		{"only invisible content", func(in *CreateInput) { in.Content = "\u200B\uFEFF" }, http.StatusUnprocessableEntity},
+		{"content too long", func(in *CreateInput) { in.Content = strings.Repeat("é", comment.MaxContentLength+1) }, http.StatusUnprocessableEntity},
+		{"missing entity", func(in *CreateInput) { in.EntityID = "" }, http.StatusUnprocessableEntity},
+		{"missing author", func(in *CreateInput) { in.AuthorName = "" }, http.StatusUnprocessableEntity},
+		{"bad format", func(in *CreateInput) { in.ContentFormat = "rtf" }, http.StatusUnprocessableEntity},
+		{"unknown parent", func(in *CreateInput) { in.ParentID = &missingParent }, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := validCreate()
+			tt.modify(&in)
+			_, err := svc.Create(context.Background(), in)
+			assertAppError(t, err, tt.status)
+		})
+	}
+}
+
+func TestService_CreateCountsGraphemes(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	limits := settings.Defaults(tenant.PlanFree)
+	limits.MaxCommentLength = 3
+	ctx := settings.NewContext(context.Background(), limits)
+
+	in := validCreate()
+	in.Content = " \u200Bcafe\u0301 "
+	_, err := svc.Create(ctx, in)
+	assertAppError(t, err, http.StatusUnprocessableEntity)
+
+	in.Content = "👨\u200D👩\u200D👧🇩🇪e\u0301"
+	c, err := svc.Create(ctx, in)
+	if err != nil {
+		t.Fatalf("expected three characters to fit, got %v", err)
+	}
+	if c.Content != "👨\u200D👩\u200D👧🇩🇪é" {
+		t.Errorf("expected normalized content, got %q", c.Content)
+	}
+}
+
+func TestService_CreateChecksAuthorEmail(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	limits := settings.Defaults(tenant.PlanFree)
+	limits.EmailDeniedDomains = []string{"spam.example"}
+	limits.BlockDisposableEmail = true
+	ctx := settings.NewContext(context.Background(), limits)
+
+	tests := []struct {
+		email string
+		want  string
+		rule  string
+	}{
+		{email: "Ada@Bücher.DE", want: "Ada@xn--bcher-kva.de"},
+		{email: "ada@example", rule: "email"},
+		{email: "ada@mail.spam.example", rule: "email_domain_denied"},
+		{email: "ada@mailinator.com", rule: "email_disposable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			in := validCreate()
+			in.AuthorEmail = &tt.email
+			c, err := svc.Create(ctx, in)
+			if tt.rule == "" {
+				if err != nil || c.AuthorEmail == nil || *c.AuthorEmail != tt.want {
+					t.Errorf("expected %s, got %v (%v)", tt.want, c, err)
+				}
+				return
+			}
+			var appErr *errors.AppError
+			if !stderrors.As(err, &appErr) || len(appErr.Errors) != 1 || appErr.Errors[0].Rule != tt.rule {
+				t.Errorf("expected %s violation, got %v", tt.rule, err)
+			}
+		})
+	}
+}
+
+func TestService_CreateRespectsTenantNestingDepth(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	limits := settings.Defaults(tenant.PlanFree)
+	limits.MaxNestingDepth = 1
+	ctx := settings.NewContext(context.Background(), limits)
+
+	root, _ := svc.Create(ctx, validCreate())
+	in := validCreate()
+	in.ParentID = &root.ID
+	reply, err := svc.Create(ctx, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	in.ParentID = &reply.ID
+	_, err = svc.Create(ctx, in)
+	assertAppError(t, err, http.StatusUnprocessableEntity)
+}
+
+func TestService_CreateReplyToOtherEntity(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	root, _ := svc.Create(context.Background(), validCreate())
+
+	in := validCreate()
+	in.EntityID = "post_2"
+	in.ParentID = &root.ID
+	_, err := svc.Create(context.Background(), in)
+	assertAppError(t, err, http.StatusBadRequest)
+}
+
+func TestService_TenantScoping(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	c, _ := svc.Create(context.Background(), validCreate())
+
+	_, err := svc.Get(context.Background(), "tenant-2", c.ID)
+	assertAppError(t, err, http.StatusNotFound)
+
+	_, err = svc.Delete(context.Background(), "tenant-2", c.ID)
+	assertAppError(t, err, http.StatusNotFound)
+}
+
+func TestService_UpdateAndDelete(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	root, _ := svc.Create(context.Background(), validCreate())
+	in := validCreate()
+	in.ParentID = &root.ID
+	svc.Create(context.Background(), in)
+
+	updated, err := svc.Update(context.Background(), UpdateInput{
+		TenantID: root.TenantID,
+		ID:       root.ID,
+		Content:  "Edited",
+		EditedBy: "author-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Content != "Edited" || !updated.IsEdited {
+		t.Errorf("expected edited content, got %q edited=%v", updated.Content, updated.IsEdited)
+	}
+
+	n, err := svc.Delete(context.Background(), root.TenantID, root.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 deleted comments, got %d", n)
+	}
+
+	_, err = svc.Get(context.Background(), root.TenantID, root.ID)
+	assertAppError(t, err, http.StatusNotFound)
+}
+
+func TestService_PublishesEvents(t *testing.T) {
+	events := &fakePublisher{}
+	svc := NewService(newFakeRepo(), cursor.NewSigner([]byte("test")), events, nil)
+
+	root, _ := svc.Create(context.Background(), validCreate())
+	svc.Update(context.Background(), UpdateInput{
+		TenantID: root.TenantID,
+		ID:       root.ID,
+		Content:  "Edited",
+		EditedBy: "author-1",
+	})
+	svc.Delete(context.Background(), root.TenantID, root.ID)
+
+	want := []comment.EventType{comment.EventCreated, comment.EventEdited, comment.EventDeleted}
+	if len(events.events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events.events))
+	}
+	for i, e := range events.events {
+		if e.Type != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], e.Type)
+		}
+		if e.TenantID != "tenant-1" || e.EntityType != "post" || e.EntityID != "post_1" || e.CommentID != root.ID {
+			t.Errorf("event %d: unexpected routing %+v", i, e)
+		}
+	}
+	if events.events[2].Deleted != 1 {
+		t.Errorf("expected 1 deleted comment, got %d", events.events[2].Deleted)
+	}
+}
+
+func TestService_NotifiesParentAuthor(t *testing.T) {
+	notifier := &fakeNotifier{replies: map[string]string{}}
+	svc := NewService(newFakeRepo(), cursor.NewSigner([]byte("test")), nil, notifier)
+
+	root, _ := svc.Create(context.Background(), validCreate())
+	in := validCreate()
+	in.ParentID = &root.ID
+	reply, err := svc.Create(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notifier.replies) != 1 || notifier.replies[reply.ID] != root.ID {
+		t.Errorf("expected only the reply to be notified, got %v", notifier.replies)
+	}
+}
+
+func TestService_List(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	for i := 0; i < 5; i++ {
+		svc.Create(context.Background(), validCreate())
+	}
+	list := func(cursor string) *ListResult {
+		t.Helper()
+		result, err := svc.List(context.Background(), ListInput{
+			TenantID:   "tenant-1",
+			EntityType: "post",
+			EntityID:   "post_1",
+			Cursor:     cursor,
+			Limit:      2,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	first := list("")
+	if len(first.Comments) != 2 || first.Next == "" || first.Prev != "" {
+		t.Fatalf("unexpected first page: %d comments next=%q prev=%q", len(first.Comments), first.Next, first.Prev)
+	}
+
+	second := list(first.Next)
+	if len(second.Comments) != 2 || second.Next == "" || second.Prev == "" {
+		t.Fatalf("unexpected second page: %d comments next=%q prev=%q", len(second.Comments), second.Next, second.Prev)
+	}
+
+	last := list(second.Next)
+	if len(last.Comments) != 1 || last.Next != "" || last.Prev == "" {
+		t.Fatalf("unexpected last page: %d comments next=%q prev=%q", len(last.Comments), last.Next, last.Prev)
+	}
+
+	back := list(second.Prev)
+	if len(back.Comments) != 2 || back.Comments[0].ID != first.Comments[0].ID || back.Prev != "" || back.Next == "" {
+		t.Errorf("expected prev of second page to be the first page, got %d comments prev=%q", len(back.Comments), back.Prev)
+	}
+}
+
+func TestService_ListInvalidInput(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	newest, _ := cursor.NewSigner([]byte("test")).Encode(cursor.Cursor{Order: "new", ID: "x"})
+	forged, _ := cursor.NewSigner([]byte("other")).Encode(cursor.Cursor{Order: "new", ID: "x"})
+
+	tests := []struct {
+		name   string
+		in     ListInput
+		status int
+	}{
+		{"bad sort", ListInput{Sort: "hot"}, http.StatusUnprocessableEntity},
+		{"forged cursor", ListInput{Cursor: forged}, http.StatusBadRequest},
+		{"cursor for other sort", ListInput{Sort: comment.SortTop, Cursor: newest}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.TenantID, tt.in.EntityType, tt.in.EntityID = "tenant-1", "post", "post_1"
+			_, err := svc.List(context.Background(), tt.in)
+			assertAppError(t, err, tt.status)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Tree shape defaults and upper bounds
+const (
+	DefaultTreeLimit        = 20
+	MaxTreeLimit            = 100
+	DefaultTreeDepth        = 3
+	MaxTreeDepth            = 10
+	DefaultTreeRepliesLimit = 5
+	MaxTreeRepliesLimit     = 50
+)
+
+// TreeInput selects a nested thread. With a Continuation the token decides
+// which level is expanded; with a RootID the tree is rooted at that comment;
+// otherwise the root comments of the entity are used.
+type TreeInput struct {
+	TenantID     string
+	EntityType   string
+	EntityID     string
+	RootID       *string
+	Continuation string
+	Sort         comment.Sort
+	Limit        int
+	Depth        int
+	RepliesLimit int
+}
+
+// TreeNode is a comment with the replies that were loaded beneath it
+type TreeNode struct {
+	Comment *comment.Comment
+	Replies []*TreeNode
+	More    *More
+}
+
+// More points at siblings that were collapsed out of a level
+type More struct {
+	Count        int
+	Continuation string
+}
+
+// Tree is one expanded level of a thread
+type Tree struct {
+	Nodes []*TreeNode
+	More  *More
+}
+
+// continuation identifies the next page of children under a parent, or of
+// root comments when ParentID is empty. Remaining is the number of replies
+// still hidden under ParentID; it is unknown for root comments.
+type continuation struct {
+	EntityType string         `json:"e"`
+	EntityID   string         `json:"i"`
+	ParentID   string         `json:"p,omitempty"`
+	Sort       comment.Sort   `json:"s"`
+	After      *cursor.Cursor `json:"a,omitempty"`
+	Remaining  int            `json:"r,omitempty"`
+}
+
+func (s *Service) encodeContinuation(c continuation) (string, error) {
+	token, err := s.cursors.Encode(c)
+	if err != nil {
+		return "", errors.InternalServer("failed to encode continuation", err)
+	}
+	return token, nil
+}
+
+func (s *Service) decodeContinuation(token string) (continuation, error) {
+	var c continuation
+	err := s.cursors.Decode(token, &c)
+	if err != nil || !c.Sort.IsValid() || (c.After != nil && c.After.Order != string(c.Sort)) {
+		return continuation{}, errors.BadRequest("invalid continuation token").WithMessageID("comment.invalid_continuation", nil)
+	}
+	return c, nil
+}
+
+// Tree loads a nested thread bounded by per-level limits. Branches that do
+// not fit carry a continuation token that expands them on a later call.
+func (s *Service) Tree(ctx context.Context, in TreeInput) (*Tree, error) {
+	limit := clamp(in.Limit, DefaultTreeLimit, MaxTreeLimit)
+	depth := clamp(in.Depth, DefaultTreeDepth, MaxTreeDepth)
+	perParent := clamp(in.RepliesLimit, DefaultTreeRepliesLimit, MaxTreeRepliesLimit)
+	sort := sortOrDefault(in.Sort)
+	var v violations
+	v.sort(sort)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	var level continuation
+	switch {
+	case in.Continuation != "":
+		t, err := s.decodeContinuation(in.Continuation)
+		if err != nil {
+			return nil, err
+		}
+		level = t
+	case in.RootID != nil:
+		root, err := s.repo.GetByID(ctx, in.TenantID, *in.RootID)
+		if err != nil {
+			return nil, mapRepoError(err, "failed to load comment")
+		}
+		nodes, err := s.expand(ctx, in.TenantID, []*comment.Comment{root}, depth, perParent, sort)
+		if err != nil {
+			return nil, err
+		}
+		return &Tree{Nodes: nodes}, nil
+	default:
+		v.required("entity_type", in.EntityType)
+		v.required("entity_id", in.EntityID)
+		if err := v.err(); err != nil {
+			return nil, err
+		}
+		level = continuation{EntityType: in.EntityType, EntityID: in.EntityID, Sort: sort}
+	}
+
+	q := comment.ListQuery{
+		TenantID:   in.TenantID,
+		EntityType: level.EntityType,
+		EntityID:   level.EntityID,
+		Sort:       level.Sort,
+		After:      level.After,
+		Limit:      limit,
+	}
+	if level.ParentID != "" {
+		q.ParentID = &level.ParentID
+	}
+	page, hasMore, err := s.page(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.expand(ctx, in.TenantID, page, depth, perParent, level.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &Tree{Nodes: nodes}
+	if hasMore {
+		next := level
+		last := page[len(page)-1].CursorFor(level.Sort)
+		next.After = &last
+		if next.Remaining > 0 {
+			next.Remaining = max(next.Remaining-len(page), 1)
+		}
+		token, err := s.encodeContinuation(next)
+		if err != nil {
+			return nil, err
+		}
+		tree.More = &More{Count: next.Remaining, Continuation: token}
+	}
+	return tree, nil
+}
+
+// expand attaches up to depth-1 levels of replies below tops and marks every
+// branch that was cut short with a continuation
+func (s *Service) expand(ctx context.Context, tenantID string, tops []*comment.Comment, depth, perParent int, sort comment.Sort) ([]*TreeNode, error) {
+	nodes := make([]*TreeNode, 0, len(tops))
+	byID := make(map[string]*TreeNode, len(tops))
+	ids := make([]string, 0, len(tops))
+	for _, c := range tops {
+		n := &TreeNode{Comment: c, Replies: []*TreeNode{}}
+		nodes = append(nodes, n)
+		byID[c.ID] = n
+		ids = append(ids, c.ID)
+	}
+
+	if depth > 1 && len(tops) > 0 {
+		maxDepth := tops[0].Depth + depth - 1
+		descendants, err := s.repo.Descendants(ctx, tenantID, ids, maxDepth, perParent, sort)
+		if err != nil {
+			return nil, mapRepoError(err, "failed to load replies")
+		}
+		// Descendants arrive shallowest first; a reply whose parent was
+		// trimmed by the per-parent limit is dropped with it.
+		for _, c := range descendants {
+			if c.ParentID == nil {
+				continue
+			}
+			parent, ok := byID[*c.ParentID]
+			if !ok {
+				continue
+			}
+			n := &TreeNode{Comment: c, Replies: []*TreeNode{}}
+			parent.Replies = append(parent.Replies, n)
+			byID[c.ID] = n
+		}
+	}
+
+	// ReplyCount also counts replies held for moderation, which the tree
+	// never shows
+	parentIDs := make([]string, 0, len(byID))
+	for id := range byID {
+		parentIDs = append(parentIDs, id)
+	}
+	counts, err := s.repo.ReplyCounts(ctx, tenantID, parentIDs)
+	if err != nil {
+		return nil, mapRepoError(err, "failed to count replies")
+	}
+
+	for id, n := range byID {
+		hidden := counts[id] - len(n.Replies)
+		if hidden <= 0 {
+			continue
+		}
+		next := continuation{
+			EntityType: n.Comment.EntityType,
+			EntityID:   n.Comment.EntityID,
+			ParentID:   n.Comment.ID,
+			Sort:       sort,
+			Remaining:  hidden,
+		}
+		if len(n.Replies) > 0 {
+			last := n.Replies[len(n.Replies)-1].Comment.CursorFor(sort)
+			next.After = &last
+		}
+		token, err := s.encodeContinuation(next)
+		if err != nil {
+			return nil, err
+		}
+		n.More = &More{Count: hidden, Continuation: token}
+	}
+	return nodes, nil
+}
+
+func sortOrDefault(s comment.Sort) comment.Sort {
+	if s == "" {
+		return comment.SortNewest
+	}
+	return s
+}
+
+func clamp(v, def, max int) int {
+	if v < 1 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,163 @@
+package comment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+)
+
+// seedThread creates roots root comments on post_1, each with replies
+// direct replies, and gives the first reply of the first root a grandchild
+func seedThread(t *testing.T, svc *Service, roots, replies int) []string {
+	t.Helper()
+	var rootIDs []string
+	for i := 0; i < roots; i++ {
+		root, err := svc.Create(context.Background(), validCreate())
+		if err != nil {
+			t.Fatalf("seed root: %v", err)
+		}
+		rootIDs = append(rootIDs, root.ID)
+		for j := 0; j < replies; j++ {
+			in := validCreate()
+			in.ParentID = &root.ID
+			reply, err := svc.Create(context.Background(), in)
+			if err != nil {
+				t.Fatalf("seed reply: %v", err)
+			}
+			if i == 0 && j == 0 {
+				in.ParentID = &reply.ID
+				if _, err := svc.Create(context.Background(), in); err != nil {
+					t.Fatalf("seed grandchild: %v", err)
+				}
+			}
+		}
+	}
+	return rootIDs
+}
+
+func TestService_Tree(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	seedThread(t, svc, 3, 4)
+
+	tree, err := svc.Tree(context.Background(), TreeInput{
+		TenantID:     "tenant-1",
+		EntityType:   "post",
+		EntityID:     "post_1",
+		Limit:        2,
+		Depth:        2,
+		RepliesLimit: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tree.Nodes) != 2 {
+		t.Fatalf("expected 2 roots, got %d", len(tree.Nodes))
+	}
+	// keyset pages do not count roots, only report that more exist
+	if tree.More == nil || tree.More.Continuation == "" {
+		t.Fatalf("expected more roots, got %+v", tree.More)
+	}
+
+	first := tree.Nodes[0]
+	if len(first.Replies) != 3 {
+		t.Fatalf("expected 3 replies, got %d", len(first.Replies))
+	}
+	if first.More == nil || first.More.Count != 1 {
+		t.Errorf("expected 1 collapsed reply, got %+v", first.More)
+	}
+	// depth 2 stops at replies; the grandchild is behind a continuation
+	if len(first.Replies[0].Replies) != 0 || first.Replies[0].More == nil {
+		t.Errorf("expected grandchild to be collapsed, got %+v", first.Replies[0])
+	}
+
+	next, err := svc.Tree(context.Background(), TreeInput{TenantID: "tenant-1", Continuation: tree.More.Continuation})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(next.Nodes) != 1 || next.More != nil {
+		t.Errorf("expected last root and no more, got %d nodes more=%+v", len(next.Nodes), next.More)
+	}
+
+	replies, err := svc.Tree(context.Background(), TreeInput{TenantID: "tenant-1", Continuation: first.More.Continuation})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(replies.Nodes) != 1 || replies.Nodes[0].Comment.Depth != 1 {
+		t.Errorf("expected the remaining reply, got %+v", replies.Nodes)
+	}
+}
+
+func TestService_TreeSkipsPendingReplies(t *testing.T) {
+	repo := newFakeRepo()
+	svc := newTestService(repo)
+	roots := seedThread(t, svc, 1, 0)
+
+	// one reply shown, one collapsed and one held for moderation
+	for _, status := range []comment.Status{comment.StatusActive, comment.StatusActive, comment.StatusPending} {
+		in := validCreate()
+		in.ParentID = &roots[0]
+		reply, err := svc.Create(context.Background(), in)
+		if err != nil {
+			t.Fatalf("seed reply: %v", err)
+		}
+		repo.comments[reply.ID].Status = status
+	}
+
+	tree, err := svc.Tree(context.Background(), TreeInput{TenantID: "tenant-1", RootID: &roots[0], RepliesLimit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root := tree.Nodes[0]
+	if len(root.Replies) != 1 || root.More == nil || root.More.Count != 1 {
+		t.Fatalf("expected 1 reply and 1 collapsed, got %d and %+v", len(root.Replies), root.More)
+	}
+
+	rest, err := svc.Tree(context.Background(), TreeInput{TenantID: "tenant-1", Continuation: root.More.Continuation})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rest.Nodes) != 1 || rest.More != nil {
+		t.Errorf("expected the last active reply and no more, got %d nodes more=%+v", len(rest.Nodes), rest.More)
+	}
+}
+
+func TestService_TreeRootedAtComment(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+	roots := seedThread(t, svc, 1, 1)
+
+	tree, err := svc.Tree(context.Background(), TreeInput{TenantID: "tenant-1", RootID: &roots[0]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tree.Nodes) != 1 || tree.Nodes[0].Comment.ID != roots[0] {
+		t.Fatalf("expected tree rooted at %s", roots[0])
+	}
+	reply := tree.Nodes[0].Replies[0]
+	if len(reply.Replies) != 1 || reply.More != nil {
+		t.Errorf("expected grandchild to be loaded, got %+v", reply)
+	}
+}
+
+func TestService_TreeInvalidInput(t *testing.T) {
+	svc := newTestService(newFakeRepo())
+
+	tests := []struct {
+		name   string
+		in     TreeInput
+		status int
+	}{
+		{"missing entity", TreeInput{TenantID: "tenant-1"}, http.StatusUnprocessableEntity},
+		{"bad token", TreeInput{TenantID: "tenant-1", Continuation: "%%%"}, http.StatusBadRequest},
+		{"bad sort", TreeInput{TenantID: "tenant-1", EntityType: "post", EntityID: "post_1", Sort: "hot"}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Tree(context.Background(), tt.in)
+			assertAppError(t, err, tt.status)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package comment
+
+import (
+	stderrors "errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/pkg/email"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/text"
+)
+
+// violations collects every invalid field of a command so they are
+// reported together
+type violations []errors.FieldError
+
+func (v *violations) add(path, rule string, params map[string]any, message string) {
+	*v = append(*v, errors.FieldError{Path: path, Rule: rule, Params: params, Message: message})
+}
+
+func (v *violations) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, "required", nil, "is required")
+	}
+}
+
+// content checks content against the schema and the tenant's
+// max_comment_length. The limit counts characters as readers see them,
+// grapheme clusters; the rune count is checked too because that is what
+// the table's CHECK constraint measures.
+func (v *violations) content(value string, limits *settings.Settings) {
+	if strings.TrimSpace(value) == "" {
+		v.add("content", "required", nil, "is required")
+		return
+	}
+	max := min(comment.MaxContentLength, limits.MaxCommentLength)
+	if text.Length(value) > max || utf8.RuneCountInString(value) > comment.MaxContentLength {
+		v.add("content", "max_comment_length", map[string]any{"max": max}, "exceeds the maximum length")
+	}
+}
+
+// email checks an optional address against the syntax rules and the
+// tenant's domain policy, returning it with its domain normalized
+func (v *violations) email(path string, value *string, policy email.Policy) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	addr, err := email.Validate(strings.TrimSpace(*value), policy)
+	if err != nil {
+		var rejected *email.Error
+		if stderrors.As(err, &rejected) {
+			*v = append(*v, rejected.FieldError(path))
+		} else {
+			v.add(path, "email", nil, "must be an email address")
+		}
+		return nil
+	}
+	normalized := addr.String()
+	return &normalized
+}
+
+func (v *violations) sort(s comment.Sort) {
+	if !s.IsValid() {
+		v.add("sort", "oneof", map[string]any{"values": []string{"new", "old", "top"}}, "must be one of new, old, top")
+	}
+}
+
+// err returns nil when nothing was collected
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.Validation(v...)
+}
@@ -0,0 +1,146 @@
+// Package like implements liking and unliking comments, announcing every
+// change of a comment's like count to its live subscribers.
+package like
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Result is the like count of a comment after a like or unlike
+type Result struct {
+	CommentID string
+	LikeCount int
+}
+
+// Comments finds the comment a like is for. comment.Repository
+// implements it.
+type Comments interface {
+	GetByID(ctx context.Context, tenantID, id string) (*comment.Comment, error)
+}
+
+// Service exposes the like use cases
+type Service struct {
+	likes    like.Repository
+	comments Comments
+	events   comment.EventPublisher
+}
+
+// NewService creates a like service storing likes in likes. comments finds
+// the entity a comment belongs to, for the comment.reactions events sent on
+// events; events may be nil when it is not wired.
+func NewService(likes like.Repository, comments Comments, events comment.EventPublisher) *Service {
+	return &Service{likes: likes, comments: comments, events: events}
+}
+
+// Like records the reaction of userID to a comment, LIKE when reaction is
+// empty. Liking again only changes the reaction.
+func (s *Service) Like(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (*Result, error) {
+	if reaction == "" {
+		reaction = like.ReactionLike
+	}
+	if err := validate(userID, reaction); err != nil {
+		return nil, err
+	}
+	c, err := s.comment(ctx, tenantID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, changed, err := s.likes.Add(ctx, tenantID, commentID, userID, reaction)
+	if err != nil {
+		return nil, mapRepoError(err, "failed to like comment")
+	}
+	if changed {
+		s.publish(ctx, c, count)
+	}
+	return &Result{CommentID: commentID, LikeCount: count}, nil
+}
+
+// Unlike removes the reaction of userID to a comment. Unliking a comment
+// the user has not liked leaves the count as it is.
+func (s *Service) Unlike(ctx context.Context, tenantID, commentID, userID string) (*Result, error) {
+	if err := validate(userID, like.ReactionLike); err != nil {
+		return nil, err
+	}
+	c, err := s.comment(ctx, tenantID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, changed, err := s.likes.Remove(ctx, tenantID, commentID, userID)
+	if err != nil {
+		return nil, mapRepoError(err, "failed to unlike comment")
+	}
+	if changed {
+		s.publish(ctx, c, count)
+	}
+	return &Result{CommentID: commentID, LikeCount: count}, nil
+}
+
+func (s *Service) comment(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	c, err := s.comments.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, mapRepoError(err, "failed to load comment")
+	}
+	return c, nil
+}
+
+// publish announces the new like count of c. The like is already
+// committed, so a failure only costs subscribers an update and is logged.
+func (s *Service) publish(ctx context.Context, c *comment.Comment, count int) {
+	if s.events == nil {
+		return
+	}
+	e := comment.NewEvent(comment.EventReactions, c)
+	e.LikeCount = count
+	if err := s.events.Publish(ctx, e); err != nil {
+		log.Printf("failed to publish %s for comment %s: %v", e.Type, e.CommentID, err)
+	}
+}
+
+func validate(userID string, reaction like.Reaction) error {
+	var fields []errors.FieldError
+	if strings.TrimSpace(userID) == "" {
+		fields = append(fields, errors.FieldError{Path: "user_id", Rule: "required", Message: "is required"})
+	} else if _, err := uuid.Parse(userID); err != nil {
+		fields = append(fields, errors.FieldError{Path: "user_id", Rule: "uuid", Message: "must be a UUID"})
+	}
+	if !reaction.IsValid() {
+		values := make([]string, len(like.Reactions))
+		for i, r := range like.Reactions {
+			values[i] = string(r)
+		}
+		fields = append(fields, errors.FieldError{
+			Path:    "reaction",
+			Rule:    "oneof",
+			Params:  map[string]any{"values": values},
+			Message: "must be one of " + strings.Join(values, ", "),
+		})
+	}
+	if len(fields) > 0 {
+		return errors.Validation(fields...)
+	}
+	return nil
+}
+
+// mapRepoError passes AppErrors through and turns a missing comment into a
+// 404
+func mapRepoError(err error, message string) error {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	if stderrors.Is(err, comment.ErrNotFound) {
+		return errors.NotFound("comment not found").WithMessageID("comment.not_found", nil)
+	}
+	return errors.InternalServer(message, err)
+}
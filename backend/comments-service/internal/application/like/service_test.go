@@ -0,0 +1,151 @@
+package like
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+const (
+	tenantID  = "tenant-1"
+	commentID = "00000000-0000-0000-0000-000000000001"
+	userID    = "00000000-0000-0000-0000-0000000000aa"
+)
+
+type fakeComments map[string]*comment.Comment
+
+func (f fakeComments) GetByID(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	c, ok := f[id]
+	if !ok || c.TenantID != tenantID {
+		return nil, comment.ErrNotFound
+	}
+	return c, nil
+}
+
+// fakeLikes keeps one reaction per comment and user, as the unique
+// constraint does
+type fakeLikes struct {
+	reactions map[string]like.Reaction
+}
+
+func (f *fakeLikes) Add(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (int, bool, error) {
+	_, existed := f.reactions[commentID+"/"+userID]
+	f.reactions[commentID+"/"+userID] = reaction
+	return len(f.reactions), !existed, nil
+}
+
+func (f *fakeLikes) Remove(ctx context.Context, tenantID, commentID, userID string) (int, bool, error) {
+	_, existed := f.reactions[commentID+"/"+userID]
+	delete(f.reactions, commentID+"/"+userID)
+	return len(f.reactions), existed, nil
+}
+
+type fakePublisher struct {
+	events []comment.Event
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, e comment.Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func newTestService() (*Service, *fakeLikes, *fakePublisher) {
+	likes := &fakeLikes{reactions: map[string]like.Reaction{}}
+	events := &fakePublisher{}
+	comments := fakeComments{commentID: {ID: commentID, TenantID: tenantID, EntityType: "post", EntityID: "p1"}}
+	return NewService(likes, comments, events), likes, events
+}
+
+func TestService_LikePublishesCount(t *testing.T) {
+	svc, likes, events := newTestService()
+
+	res, err := svc.Like(context.Background(), tenantID, commentID, userID, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.LikeCount != 1 {
+		t.Errorf("expected like count 1, got %d", res.LikeCount)
+	}
+	if got := likes.reactions[commentID+"/"+userID]; got != like.ReactionLike {
+		t.Errorf("expected the default reaction LIKE, got %q", got)
+	}
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events.events))
+	}
+	e := events.events[0]
+	if e.Type != comment.EventReactions || e.LikeCount != 1 || e.EntityType != "post" || e.EntityID != "p1" {
+		t.Errorf("expected a reactions event for post/p1 with count 1, got %+v", e)
+	}
+
+	// Changing the reaction keeps the count, so nothing is announced
+	if _, err := svc.Like(context.Background(), tenantID, commentID, userID, like.ReactionLove); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events.events) != 1 {
+		t.Errorf("expected no event for a changed reaction, got %d events", len(events.events))
+	}
+}
+
+func TestService_UnlikePublishesCount(t *testing.T) {
+	svc, _, events := newTestService()
+
+	res, err := svc.Unlike(context.Background(), tenantID, commentID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.LikeCount != 0 || len(events.events) != 0 {
+		t.Errorf("expected unliking an unliked comment to change nothing, got count %d and %d events", res.LikeCount, len(events.events))
+	}
+
+	if _, err := svc.Like(context.Background(), tenantID, commentID, userID, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.Unlike(context.Background(), tenantID, commentID, userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events.events))
+	}
+	if e := events.events[1]; e.Type != comment.EventReactions || e.LikeCount != 0 {
+		t.Errorf("expected a reactions event with count 0, got %+v", e)
+	}
+}
+
+func TestService_LikeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		tenantID  string
+		commentID string
+		userID    string
+		reaction  like.Reaction
+		status    int
+	}{
+		{"missing user", tenantID, commentID, "", "", http.StatusUnprocessableEntity},
+		{"user not a uuid", tenantID, commentID, "alice", "", http.StatusUnprocessableEntity},
+		{"unknown reaction", tenantID, commentID, userID, "MEH", http.StatusUnprocessableEntity},
+		{"missing comment", tenantID, "00000000-0000-0000-0000-000000000099", userID, "", http.StatusNotFound},
+		{"other tenant", "tenant-2", commentID, userID, "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, events := newTestService()
+			_, err := svc.Like(context.Background(), tt.tenantID, tt.commentID, tt.userID, tt.reaction)
+			var appErr *errors.AppError
+			if !stderrors.As(err, &appErr) {
+				t.Fatalf("expected an AppError, got %v", err)
+			}
+			if appErr.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, appErr.StatusCode)
+			}
+			if len(events.events) != 0 {
+				t.Errorf("expected no events, got %d", len(events.events))
+			}
+		})
+	}
+}
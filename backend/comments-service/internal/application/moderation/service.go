@@ -0,0 +1,120 @@
+// Package moderation implements the review of reported and held comments
+// on top of the moderation repository.
+package moderation
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/moderation"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/text"
+)
+
+// MaxNoteLength caps a moderation note in characters
+const MaxNoteLength = 1000
+
+// ModerateInput is the command for deciding on a comment awaiting review
+type ModerateInput struct {
+	TenantID    string
+	ID          string
+	Decision    moderation.Decision
+	ModeratorID string
+	Note        *string
+}
+
+// Service exposes the moderation use cases
+type Service struct {
+	repo moderation.Repository
+}
+
+// NewService creates a moderation service backed by repo
+func NewService(repo moderation.Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Queue returns the comments awaiting review, at most limit of them;
+// limit is normalized like a page size
+func (s *Service) Queue(ctx context.Context, tenantID string, limit int) ([]*moderation.Item, error) {
+	items, err := s.repo.Queue(ctx, tenantID, cursor.Limit(limit))
+	if err != nil {
+		return nil, mapRepoError(err, "failed to load moderation queue")
+	}
+	return items, nil
+}
+
+// Flag reports a comment, hiding it until a moderator reviews it
+func (s *Service) Flag(ctx context.Context, tenantID, id string) (*moderation.Item, error) {
+	item, err := s.repo.Flag(ctx, tenantID, id)
+	if err != nil {
+		return nil, mapRepoError(err, "failed to flag comment")
+	}
+	return item, nil
+}
+
+// Moderate approves or rejects a comment awaiting review
+func (s *Service) Moderate(ctx context.Context, in ModerateInput) (*moderation.Item, error) {
+	var fields []errors.FieldError
+	if !in.Decision.IsValid() {
+		fields = append(fields, errors.FieldError{
+			Path:    "decision",
+			Rule:    "oneof",
+			Params:  map[string]any{"values": []string{"APPROVE", "REJECT"}},
+			Message: "must be one of APPROVE, REJECT",
+		})
+	}
+	if strings.TrimSpace(in.ModeratorID) == "" {
+		fields = append(fields, errors.FieldError{Path: "moderator_id", Rule: "required", Message: "is required"})
+	} else if _, err := uuid.Parse(in.ModeratorID); err != nil {
+		fields = append(fields, errors.FieldError{Path: "moderator_id", Rule: "uuid", Message: "must be a UUID"})
+	}
+	if in.Note != nil {
+		note := text.Clean(*in.Note)
+		if text.Length(note) > MaxNoteLength {
+			fields = append(fields, errors.FieldError{
+				Path:    "note",
+				Rule:    "max",
+				Params:  map[string]any{"max": MaxNoteLength},
+				Message: "must be at most 1000 characters",
+			})
+		}
+		in.Note = &note
+		if note == "" {
+			in.Note = nil
+		}
+	}
+	if len(fields) > 0 {
+		return nil, errors.Validation(fields...)
+	}
+
+	item, err := s.repo.Decide(ctx, in.TenantID, in.ID, moderation.Review{
+		Decision:    in.Decision,
+		ModeratorID: in.ModeratorID,
+		Note:        in.Note,
+	})
+	if err != nil {
+		if stderrors.Is(err, moderation.ErrNotQueued) {
+			return nil, errors.Conflict("comment is not awaiting moderation").WithMessageID("moderation.not_queued", nil)
+		}
+		return nil, mapRepoError(err, "failed to moderate comment")
+	}
+	return item, nil
+}
+
+// mapRepoError passes AppErrors through and turns a missing comment into a
+// 404
+func mapRepoError(err error, message string) error {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	if stderrors.Is(err, comment.ErrNotFound) {
+		return errors.NotFound("comment not found").WithMessageID("comment.not_found", nil)
+	}
+	return errors.InternalServer(message, err)
+}
@@ -0,0 +1,164 @@
+package moderation
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/moderation"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+const (
+	tenantID    = "tenant-1"
+	commentID   = "00000000-0000-0000-0000-000000000001"
+	moderatorID = "00000000-0000-0000-0000-0000000000aa"
+)
+
+type fakeRepo struct {
+	items map[string]*moderation.Item
+	limit int
+}
+
+func newFakeRepo(status comment.Status) *fakeRepo {
+	return &fakeRepo{items: map[string]*moderation.Item{
+		commentID: {Comment: &comment.Comment{ID: commentID, TenantID: tenantID, Status: status}},
+	}}
+}
+
+func (r *fakeRepo) get(tenantID, id string) (*moderation.Item, error) {
+	item, ok := r.items[id]
+	if !ok || item.Comment.TenantID != tenantID {
+		return nil, comment.ErrNotFound
+	}
+	return item, nil
+}
+
+func (r *fakeRepo) Queue(ctx context.Context, tenantID string, limit int) ([]*moderation.Item, error) {
+	r.limit = limit
+	var items []*moderation.Item
+	for _, item := range r.items {
+		if item.Comment.Status == comment.StatusFlagged || item.Comment.Status == comment.StatusPending {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeRepo) Flag(ctx context.Context, tenantID, id string) (*moderation.Item, error) {
+	item, err := r.get(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	item.FlaggedCount++
+	if item.Comment.Status == comment.StatusActive {
+		item.Comment.Status = comment.StatusFlagged
+	}
+	return item, nil
+}
+
+func (r *fakeRepo) Decide(ctx context.Context, tenantID, id string, rv moderation.Review) (*moderation.Item, error) {
+	item, err := r.get(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if s := item.Comment.Status; s != comment.StatusFlagged && s != comment.StatusPending {
+		return nil, moderation.ErrNotQueued
+	}
+	item.Comment.Status = rv.Decision.Status()
+	item.ModeratedBy = &rv.ModeratorID
+	item.ModerationNote = rv.Note
+	return item, nil
+}
+
+func TestService_FlagQueuesComment(t *testing.T) {
+	repo := newFakeRepo(comment.StatusActive)
+	svc := NewService(repo)
+
+	item, err := svc.Flag(context.Background(), tenantID, commentID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Comment.Status != comment.StatusFlagged || item.FlaggedCount != 1 {
+		t.Errorf("expected a flagged comment reported once, got %s with %d reports", item.Comment.Status, item.FlaggedCount)
+	}
+
+	queue, err := svc.Queue(context.Background(), tenantID, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queue) != 1 {
+		t.Errorf("expected the flagged comment in the queue, got %d items", len(queue))
+	}
+	if repo.limit != cursor.DefaultLimit {
+		t.Errorf("expected the default limit %d, got %d", cursor.DefaultLimit, repo.limit)
+	}
+}
+
+func TestService_Moderate(t *testing.T) {
+	tests := []struct {
+		decision moderation.Decision
+		status   comment.Status
+	}{
+		{moderation.DecisionApprove, comment.StatusActive},
+		{moderation.DecisionReject, comment.StatusSpam},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.decision), func(t *testing.T) {
+			svc := NewService(newFakeRepo(comment.StatusPending))
+			note := "  checked by hand  "
+			item, err := svc.Moderate(context.Background(), ModerateInput{
+				TenantID:    tenantID,
+				ID:          commentID,
+				Decision:    tt.decision,
+				ModeratorID: moderatorID,
+				Note:        &note,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if item.Comment.Status != tt.status {
+				t.Errorf("expected status %s, got %s", tt.status, item.Comment.Status)
+			}
+			if item.ModerationNote == nil || *item.ModerationNote != "checked by hand" {
+				t.Errorf("expected the cleaned note, got %v", item.ModerationNote)
+			}
+		})
+	}
+}
+
+func TestService_ModerateErrors(t *testing.T) {
+	long := strings.Repeat("a", MaxNoteLength+1)
+	tests := []struct {
+		name   string
+		status comment.Status
+		in     ModerateInput
+		want   int
+	}{
+		{"unknown decision", comment.StatusFlagged, ModerateInput{ID: commentID, Decision: "MAYBE", ModeratorID: moderatorID}, http.StatusUnprocessableEntity},
+		{"moderator not a uuid", comment.StatusFlagged, ModerateInput{ID: commentID, Decision: moderation.DecisionApprove, ModeratorID: "bob"}, http.StatusUnprocessableEntity},
+		{"note too long", comment.StatusFlagged, ModerateInput{ID: commentID, Decision: moderation.DecisionApprove, ModeratorID: moderatorID, Note: &long}, http.StatusUnprocessableEntity},
+		{"not queued", comment.StatusActive, ModerateInput{ID: commentID, Decision: moderation.DecisionApprove, ModeratorID: moderatorID}, http.StatusConflict},
+		{"missing comment", comment.StatusFlagged, ModerateInput{ID: "00000000-0000-0000-0000-000000000099", Decision: moderation.DecisionApprove, ModeratorID: moderatorID}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(newFakeRepo(tt.status))
+			tt.in.TenantID = tenantID
+			_, err := svc.Moderate(context.Background(), tt.in)
+			var appErr *errors.AppError
+			if !stderrors.As(err, &appErr) {
+				t.Fatalf("expected an AppError, got %v", err)
+			}
+			if appErr.StatusCode != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, appErr.StatusCode)
+			}
+		})
+	}
+}
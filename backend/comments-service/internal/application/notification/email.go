@@ -0,0 +1,82 @@
+// Package notification renders the emails sent to users about activity on
+// their comments, in each recipient's language and timezone, and queues
+// them for the mailer.
+package notification
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+	"github.com/ayushvyasgit/comments-service/pkg/utils"
+)
+
+// excerptLength caps how much of a comment is quoted in an email
+const excerptLength = 280
+
+// Email is a rendered message ready for a mailer
+type Email struct {
+	To       string `json:"to"`
+	Language string `json:"language"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+}
+
+// Recipient is the user an email is addressed to
+type Recipient struct {
+	TenantID string
+	UserID   string
+	Name     string
+	Email    string
+}
+
+// Renderer builds emails from the i18n catalog
+type Renderer struct {
+	messages *i18n.Catalog
+	users    user.Repository
+}
+
+// NewRenderer creates a renderer reading recipients' preferences from users
+func NewRenderer(messages *i18n.Catalog, users user.Repository) *Renderer {
+	return &Renderer{messages: messages, users: users}
+}
+
+// Reply renders the email telling to that reply answered their comment. It
+// returns nil when the recipient turned email notifications off.
+func (r *Renderer) Reply(ctx context.Context, to Recipient, reply *comment.Comment) (*Email, error) {
+	prefs, err := r.users.Preferences(ctx, to.TenantID, to.UserID)
+	if stderrors.Is(err, user.ErrNotFound) {
+		defaults := user.DefaultPreferences()
+		prefs, err = &defaults, nil
+	}
+	if err != nil {
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, errors.InternalServer("failed to load user preferences", err)
+	}
+	if !prefs.EmailNotifications {
+		return nil, nil
+	}
+
+	loc := r.messages.Locale(prefs.Language, "", prefs.Timezone)
+	args := map[string]any{
+		"recipient": to.Name,
+		"author":    reply.AuthorName,
+		"excerpt":   utils.TruncateString(reply.Content, excerptLength),
+		"posted_at": reply.CreatedAt,
+	}
+	subject, err := loc.Render("email.reply.subject", args)
+	if err != nil {
+		return nil, errors.InternalServer("failed to render email", err)
+	}
+	body, err := loc.Render("email.reply.body", args)
+	if err != nil {
+		return nil, errors.InternalServer("failed to render email", err)
+	}
+	return &Email{To: to.Email, Language: loc.Language.String(), Subject: subject, Body: body}, nil
+}
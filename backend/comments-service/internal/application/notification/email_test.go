@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+)
+
+type fakeUsers map[string]user.Preferences
+
+func (f fakeUsers) Preferences(ctx context.Context, tenantID, userID string) (*user.Preferences, error) {
+	prefs, ok := f[userID]
+	if !ok {
+		return nil, user.ErrNotFound
+	}
+	return &prefs, nil
+}
+
+func TestRenderer_Reply(t *testing.T) {
+	users := fakeUsers{
+		"de":  {EmailNotifications: true, Language: "de", Timezone: "Europe/Berlin"},
+		"off": {EmailNotifications: false, Language: "en", Timezone: "UTC"},
+	}
+	r := NewRenderer(i18n.Default(), users)
+	reply := &comment.Comment{
+		AuthorName: "Ada",
+		Content:    "Guter Punkt!",
+		CreatedAt:  time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		userID   string
+		language string
+		subject  string
+		body     []string
+	}{
+		{
+			name:     "recipient language and timezone",
+			userID:   "de",
+			language: "de",
+			subject:  "Ada hat auf deinen Kommentar geantwortet",
+			body:     []string{"Hallo Grace,", "01.03.2024 13:30 CET", "Guter Punkt!"},
+		},
+		{
+			name:     "unknown users get the defaults",
+			userID:   "missing",
+			language: "en",
+			subject:  "Ada replied to your comment",
+			body:     []string{"Hi Grace,", "Mar 1, 2024 at 12:30 PM UTC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := r.Reply(context.Background(), Recipient{TenantID: "t1", UserID: tt.userID, Name: "Grace", Email: "grace@example.com"}, reply)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if email.Language != tt.language || email.Subject != tt.subject {
+				t.Errorf("expected %s %q, got %s %q", tt.language, tt.subject, email.Language, email.Subject)
+			}
+			for _, want := range tt.body {
+				if !strings.Contains(email.Body, want) {
+					t.Errorf("expected body to contain %q, got %q", want, email.Body)
+				}
+			}
+		})
+	}
+
+	email, err := r.Reply(context.Background(), Recipient{TenantID: "t1", UserID: "off"}, reply)
+	if err != nil || email != nil {
+		t.Errorf("expected no email when notifications are off, got %v (%v)", email, err)
+	}
+}
@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// EmailQueue is the queue the mailer reads rendered emails from
+const EmailQueue = "notifications.email"
+
+// Publisher queues messages for other services
+type Publisher interface {
+	Publish(ctx context.Context, queue string, v any) error
+}
+
+// Notifier emails authors about replies to their comments
+type Notifier struct {
+	renderer  *Renderer
+	publisher Publisher
+}
+
+// NewNotifier creates a notifier queueing the emails of renderer on
+// publisher
+func NewNotifier(renderer *Renderer, publisher Publisher) *Notifier {
+	return &Notifier{renderer: renderer, publisher: publisher}
+}
+
+// NotifyReply queues the email telling the author of parent about reply.
+// Authors without an email address, or replying to themselves, get none.
+func (n *Notifier) NotifyReply(ctx context.Context, parent, reply *comment.Comment) error {
+	if parent.AuthorEmail == nil || parent.AuthorID == reply.AuthorID {
+		return nil
+	}
+	to := Recipient{
+		TenantID: parent.TenantID,
+		UserID:   parent.AuthorID,
+		Name:     parent.AuthorName,
+		Email:    *parent.AuthorEmail,
+	}
+	email, err := n.renderer.Reply(ctx, to, reply)
+	if err != nil || email == nil {
+		return err
+	}
+	if err := n.publisher.Publish(ctx, EmailQueue, email); err != nil {
+		return errors.InternalServer("failed to queue email", err)
+	}
+	return nil
+}
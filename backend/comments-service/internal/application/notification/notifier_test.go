@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+	"github.com/ayushvyasgit/comments-service/pkg/utils"
+)
+
+type fakeQueue struct {
+	queue    string
+	messages []any
+}
+
+func (f *fakeQueue) Publish(ctx context.Context, queue string, v any) error {
+	f.queue = queue
+	f.messages = append(f.messages, v)
+	return nil
+}
+
+func TestNotifier_NotifyReply(t *testing.T) {
+	users := fakeUsers{"grace": {EmailNotifications: true, Language: "fr", Timezone: "UTC"}}
+	parent := &comment.Comment{TenantID: "t1", AuthorID: "grace", AuthorName: "Grace", AuthorEmail: utils.StringPtr("grace@example.com")}
+	reply := &comment.Comment{AuthorID: "ada", AuthorName: "Ada", Content: "Bien vu"}
+
+	tests := []struct {
+		name   string
+		parent *comment.Comment
+		reply  *comment.Comment
+		queued bool
+	}{
+		{"reply to another author", parent, reply, true},
+		{"author without email", &comment.Comment{TenantID: "t1", AuthorID: "grace"}, reply, false},
+		{"reply to oneself", parent, &comment.Comment{AuthorID: "grace", Content: "Edit: typo"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := &fakeQueue{}
+			n := NewNotifier(NewRenderer(i18n.Default(), users), queue)
+			if err := n.NotifyReply(context.Background(), tt.parent, tt.reply); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if queued := len(queue.messages) == 1; queued != tt.queued {
+				t.Fatalf("expected queued %v, got %d messages", tt.queued, len(queue.messages))
+			}
+			if !tt.queued {
+				return
+			}
+			email, ok := queue.messages[0].(*Email)
+			if queue.queue != EmailQueue || !ok || email.To != "grace@example.com" || email.Language != "fr" {
+				t.Errorf("expected a French email to grace on %s, got %+v on %s", EmailQueue, queue.messages[0], queue.queue)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Package tenant resolves the tenant behind an API key for every transport.
+package tenant
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/apikey"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Authenticator maps API keys to active tenants
+type Authenticator struct {
+	repo   tenant.Repository
+	hasher *apikey.Hasher
+}
+
+// NewAuthenticator creates an authenticator backed by repo, looking keys up
+// by their hashes under hasher's peppers
+func NewAuthenticator(repo tenant.Repository, hasher *apikey.Hasher) *Authenticator {
+	return &Authenticator{repo: repo, hasher: hasher}
+}
+
+// Authenticate returns the active tenant owning apiKey. Missing, unknown
+// and inactive keys are reported as Unauthorized.
+func (a *Authenticator) Authenticate(ctx context.Context, apiKey string) (*tenant.Tenant, error) {
+	if apiKey == "" {
+		return nil, errors.Unauthorized("missing API key").WithMessageID("auth.missing_api_key", nil)
+	}
+
+	// Mistyped and made-up keys of the current format fail their checksum
+	// and never reach the database
+	if err := apikey.Check(apiKey); stderrors.Is(err, apikey.ErrMalformed) {
+		return nil, invalidAPIKey()
+	}
+
+	candidates := a.hasher.Candidates(apiKey)
+	hashes := make([]string, len(candidates))
+	for i, c := range candidates {
+		hashes[i] = c.Value
+	}
+	t, matched, err := a.repo.FindByAPIKeyHash(ctx, hashes)
+	if err != nil {
+		if stderrors.Is(err, tenant.ErrNotFound) {
+			return nil, invalidAPIKey()
+		}
+		return nil, errors.InternalServer("failed to resolve tenant", err)
+	}
+	// Keys found under an older pepper are moved to the current one, so the
+	// old pepper can be retired once no key is left on it
+	if current := candidates[0]; matched != current.Value {
+		if err := a.repo.RehashAPIKey(ctx, matched, current.Value, current.Version); err != nil {
+			log.Printf("failed to rehash API key of tenant %s: %v", t.ID, err)
+		}
+	}
+	if !t.IsActive() {
+		return nil, errors.Unauthorized("tenant is not active").WithMessageID("auth.tenant_inactive", nil)
+	}
+	return t, nil
+}
+
+func invalidAPIKey() *errors.AppError {
+	return errors.Unauthorized("invalid API key").WithMessageID("auth.invalid_api_key", nil)
+}
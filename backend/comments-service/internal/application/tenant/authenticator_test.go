@@ -0,0 +1,91 @@
+package tenant
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/apikey"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// fakeKeys maps stored key hashes to the status of their tenant
+type fakeKeys struct {
+	hashes  map[string]tenant.Status
+	lookups int
+	rehash  []string
+}
+
+func (f *fakeKeys) FindByAPIKeyHash(ctx context.Context, hashes []string) (*tenant.Tenant, string, error) {
+	f.lookups++
+	for _, h := range hashes {
+		if status, ok := f.hashes[h]; ok {
+			return &tenant.Tenant{ID: "tenant-1", Status: status}, h, nil
+		}
+	}
+	return nil, "", tenant.ErrNotFound
+}
+
+func (f *fakeKeys) RehashAPIKey(ctx context.Context, oldHash, newHash string, pepperVersion int) error {
+	f.hashes[newHash] = f.hashes[oldHash]
+	delete(f.hashes, oldHash)
+	f.rehash = append(f.rehash, newHash)
+	return nil
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	oldPepper := apikey.Pepper{Version: 1, Secret: []byte(strings.Repeat("o", apikey.MinPepperLength))}
+	newPepper := apikey.Pepper{Version: 2, Secret: []byte(strings.Repeat("n", apikey.MinPepperLength))}
+	before, _ := apikey.NewHasher([]apikey.Pepper{oldPepper}, false)
+	hasher, _ := apikey.NewHasher([]apikey.Pepper{newPepper, oldPepper}, true)
+	legacy, _ := apikey.NewHasher(nil, true)
+
+	key, _ := apikey.Generate(apikey.EnvLive)
+	suspended, _ := apikey.Generate(apikey.EnvLive)
+	malformed := key[:len(key)-6] + "000000"
+
+	tests := []struct {
+		name    string
+		hashes  map[string]tenant.Status
+		key     string
+		code    int
+		rehash  bool
+		lookups int
+	}{
+		{name: "current pepper", hashes: map[string]tenant.Status{hasher.Hash(key).Value: tenant.StatusActive}, key: key, lookups: 1},
+		{name: "older pepper", hashes: map[string]tenant.Status{before.Hash(key).Value: tenant.StatusActive}, key: key, rehash: true, lookups: 1},
+		{name: "older key format", hashes: map[string]tenant.Status{legacy.Hash("3f9a0c1e").Value: tenant.StatusActive}, key: "3f9a0c1e", rehash: true, lookups: 1},
+		{name: "missing", key: "", code: http.StatusUnauthorized},
+		{name: "unknown", key: key, code: http.StatusUnauthorized, lookups: 1},
+		{name: "bad checksum", hashes: map[string]tenant.Status{hasher.Hash(malformed).Value: tenant.StatusActive}, key: malformed, code: http.StatusUnauthorized},
+		{name: "inactive tenant", hashes: map[string]tenant.Status{hasher.Hash(suspended).Value: tenant.StatusSuspended}, key: suspended, code: http.StatusUnauthorized, lookups: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeKeys{hashes: tt.hashes}
+			if repo.hashes == nil {
+				repo.hashes = map[string]tenant.Status{}
+			}
+			got, err := NewAuthenticator(repo, hasher).Authenticate(context.Background(), tt.key)
+			if tt.code != 0 {
+				var appErr *errors.AppError
+				if !stderrors.As(err, &appErr) || appErr.StatusCode != tt.code {
+					t.Errorf("expected status %d, got %v", tt.code, err)
+				}
+			} else if err != nil || got.ID != "tenant-1" {
+				t.Errorf("expected tenant-1, got %+v (%v)", got, err)
+			}
+			if repo.lookups != tt.lookups {
+				t.Errorf("expected %d lookups, got %d", tt.lookups, repo.lookups)
+			}
+			if rehashed := slices.Equal(repo.rehash, []string{hasher.Hash(tt.key).Value}); rehashed != tt.rehash {
+				t.Errorf("expected rehash %v, got %v", tt.rehash, repo.rehash)
+			}
+		})
+	}
+}
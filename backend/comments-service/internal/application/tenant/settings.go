@@ -0,0 +1,160 @@
+package tenant
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// SettingsCache keeps the resolved settings of recently seen tenants so
+// requests do not decode the JSONB columns every time. It holds at most size
+// tenants, evicting the least recently used. Entries are dropped when a
+// replica announces a change, and after ttl in case that announcement is
+// lost.
+type SettingsCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// recent orders the entries, most recently used first
+	recent *list.List
+}
+
+type settingsEntry struct {
+	tenantID string
+	settings *settings.Settings
+	expires  time.Time
+}
+
+// NewSettingsCache creates a cache holding the settings of up to size
+// tenants for at most ttl
+func NewSettingsCache(size int, ttl time.Duration) *SettingsCache {
+	return &SettingsCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// Resolve returns the settings of t, decoding them on a miss. A nil cache
+// decodes them every time. The result is shared and must not be modified.
+func (c *SettingsCache) Resolve(t *tenant.Tenant) *settings.Settings {
+	if c != nil {
+		if s, ok := c.get(t.ID); ok {
+			return s
+		}
+	}
+
+	s, err := settings.Resolve(t.Plan, t.Features, t.Settings)
+	if err != nil {
+		log.Printf("Tenant %s has malformed settings, using plan defaults: %v", t.ID, err)
+	}
+	if c != nil {
+		c.put(t.ID, s)
+	}
+	return s
+}
+
+// Invalidate drops the cached settings of tenantID
+func (c *SettingsCache) Invalidate(tenantID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[tenantID]; ok {
+		c.recent.Remove(el)
+		delete(c.entries, tenantID)
+	}
+}
+
+func (c *SettingsCache) get(tenantID string) (*settings.Settings, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[tenantID]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*settingsEntry)
+	if !c.now().Before(e.expires) {
+		c.recent.Remove(el)
+		delete(c.entries, tenantID)
+		return nil, false
+	}
+	c.recent.MoveToFront(el)
+	return e.settings, true
+}
+
+func (c *SettingsCache) put(tenantID string, s *settings.Settings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &settingsEntry{tenantID: tenantID, settings: s, expires: c.now().Add(c.ttl)}
+	if el, ok := c.entries[tenantID]; ok {
+		el.Value = e
+		c.recent.MoveToFront(el)
+		return
+	}
+	c.entries[tenantID] = c.recent.PushFront(e)
+	for c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*settingsEntry).tenantID)
+	}
+}
+
+// SettingsService updates tenant features and settings
+type SettingsService struct {
+	repo        settings.Repository
+	cache       *SettingsCache
+	invalidator settings.Invalidator
+}
+
+// NewSettingsService creates a service storing updates in repo. cache is
+// invalidated locally and invalidator tells the other replicas.
+func NewSettingsService(repo settings.Repository, cache *SettingsCache, invalidator settings.Invalidator) *SettingsService {
+	return &SettingsService{repo: repo, cache: cache, invalidator: invalidator}
+}
+
+// Update validates the partial features and settings against the schema
+// and the tenant's plan, and merges them into the tenant's stored values
+func (s *SettingsService) Update(ctx context.Context, tenantID string, plan tenant.Plan, features, values json.RawMessage) error {
+	if err := settings.Validate(plan, features, values); err != nil {
+		var verr *settings.ValidationError
+		if !stderrors.As(err, &verr) {
+			return errors.InternalServer("failed to validate tenant settings", err)
+		}
+		violations := make([]errors.FieldError, len(verr.Errors))
+		for i, fe := range verr.Errors {
+			violations[i] = errors.FieldError{Path: fe.Field, Rule: fe.Rule, Params: fe.Params, Message: fe.Message}
+		}
+		return errors.Validation(violations...)
+	}
+
+	if err := s.repo.UpdateSettings(ctx, tenantID, features, values); err != nil {
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			return appErr
+		}
+		if stderrors.Is(err, tenant.ErrNotFound) {
+			return errors.NotFound("tenant not found").WithMessageID("tenant.not_found", nil)
+		}
+		return errors.InternalServer("failed to update tenant settings", err)
+	}
+
+	s.cache.Invalidate(tenantID)
+	// The change is committed; replicas that miss it catch up after the
+	// cache TTL
+	if err := s.invalidator.Invalidate(ctx, tenantID); err != nil {
+		log.Printf("failed to announce settings change of tenant %s: %v", tenantID, err)
+	}
+	return nil
+}
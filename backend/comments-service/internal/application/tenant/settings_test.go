@@ -0,0 +1,120 @@
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+type fakeSettingsRepo struct {
+	updates int
+}
+
+func (r *fakeSettingsRepo) UpdateSettings(ctx context.Context, tenantID string, features, values json.RawMessage) error {
+	if tenantID != "tenant-1" {
+		return tenant.ErrNotFound
+	}
+	r.updates++
+	return nil
+}
+
+type fakeInvalidator struct {
+	ids []string
+}
+
+func (f *fakeInvalidator) Invalidate(ctx context.Context, tenantID string) error {
+	f.ids = append(f.ids, tenantID)
+	return nil
+}
+
+func TestSettingsCache(t *testing.T) {
+	cache := NewSettingsCache(2, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	tn := &tenant.Tenant{ID: "tenant-1", Plan: tenant.PlanFree, Settings: json.RawMessage(`{"max_nesting_depth": 3}`)}
+	first := cache.Resolve(tn)
+	if first.MaxNestingDepth != 3 {
+		t.Fatalf("expected depth 3, got %d", first.MaxNestingDepth)
+	}
+
+	// Served from the cache until invalidated or expired
+	tn.Settings = json.RawMessage(`{"max_nesting_depth": 4}`)
+	if cache.Resolve(tn) != first {
+		t.Error("expected cached settings")
+	}
+	cache.Invalidate("tenant-1")
+	if got := cache.Resolve(tn); got.MaxNestingDepth != 4 {
+		t.Errorf("expected settings decoded again after invalidation, got %d", got.MaxNestingDepth)
+	}
+
+	tn.Settings = json.RawMessage(`{"max_nesting_depth": 6}`)
+	now = now.Add(2 * time.Minute)
+	if got := cache.Resolve(tn); got.MaxNestingDepth != 6 {
+		t.Errorf("expected settings decoded again after the TTL, got %d", got.MaxNestingDepth)
+	}
+
+	// The least recently used tenant is evicted beyond size
+	cached := cache.Resolve(tn)
+	cache.Resolve(&tenant.Tenant{ID: "tenant-2", Plan: tenant.PlanFree})
+	cache.Resolve(tn)
+	cache.Resolve(&tenant.Tenant{ID: "tenant-3", Plan: tenant.PlanFree})
+	if cache.Resolve(tn) != cached {
+		t.Error("expected the recently used tenant to stay cached")
+	}
+	if cache.recent.Len() != 2 {
+		t.Errorf("expected 2 cached tenants, got %d", cache.recent.Len())
+	}
+	if _, ok := cache.entries["tenant-2"]; ok {
+		t.Error("expected the least recently used tenant to be evicted")
+	}
+
+	var uncached *SettingsCache
+	if got := uncached.Resolve(tn); got.MaxNestingDepth != 6 {
+		t.Errorf("expected a nil cache to decode the settings, got %d", got.MaxNestingDepth)
+	}
+}
+
+func TestSettingsService_Update(t *testing.T) {
+	repo := &fakeSettingsRepo{}
+	invalidator := &fakeInvalidator{}
+	svc := NewSettingsService(repo, NewSettingsCache(10, time.Minute), invalidator)
+	ctx := context.Background()
+
+	if err := svc.Update(ctx, "tenant-1", tenant.PlanFree, nil, json.RawMessage(`{"max_nesting_depth": 4}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.updates != 1 || len(invalidator.ids) != 1 || invalidator.ids[0] != "tenant-1" {
+		t.Errorf("expected one stored and announced update, got %d and %v", repo.updates, invalidator.ids)
+	}
+
+	tests := []struct {
+		name     string
+		tenantID string
+		features string
+		values   string
+		status   int
+	}{
+		{"invalid", "tenant-1", "", `{"max_nesting_depth": -1}`, http.StatusUnprocessableEntity},
+		{"feature outside plan", "tenant-1", `{"webhooks_enabled": true}`, "", http.StatusUnprocessableEntity},
+		{"unknown tenant", "tenant-2", "", `{"max_nesting_depth": 4}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Update(ctx, tt.tenantID, tenant.PlanFree, json.RawMessage(tt.features), json.RawMessage(tt.values))
+			var appErr *errors.AppError
+			if !stderrors.As(err, &appErr) || appErr.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %v", tt.status, err)
+			}
+		})
+	}
+	if repo.updates != 1 || len(invalidator.ids) != 1 {
+		t.Errorf("expected rejected updates not to be stored or announced, got %d and %v", repo.updates, invalidator.ids)
+	}
+}
@@ -0,0 +1,105 @@
+package tenant
+
+import (
+	"context"
+	stderrors "errors"
+	"slices"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/subdomain"
+)
+
+// suggestionCount is how many free alternatives are offered for a name
+// that cannot be had. Twice as many candidates are looked up, since some
+// will be taken too.
+const suggestionCount = 3
+
+// SubdomainAvailability answers whether a tenant can be provisioned on a
+// subdomain
+type SubdomainAvailability struct {
+	// Subdomain is the form stored in tenants.subdomain
+	Subdomain string `json:"subdomain"`
+	// Display is the form shown to users, which differs for
+	// internationalized names
+	Display   string `json:"display"`
+	Available bool   `json:"available"`
+	// Reason is reserved or taken when the name is not available
+	Reason      subdomain.Reason `json:"reason,omitempty"`
+	Suggestions []string         `json:"suggestions,omitempty"`
+}
+
+// SubdomainService applies the subdomain policy for tenant provisioning
+type SubdomainService struct {
+	policy *subdomain.Policy
+	repo   tenant.SubdomainRepository
+}
+
+// NewSubdomainService creates a service checking names against policy and
+// the subdomains registered in repo. A nil policy is subdomain.DefaultPolicy.
+func NewSubdomainService(policy *subdomain.Policy, repo tenant.SubdomainRepository) *SubdomainService {
+	if policy == nil {
+		policy = subdomain.DefaultPolicy()
+	}
+	return &SubdomainService{policy: policy, repo: repo}
+}
+
+// Check validates name. Malformed, confusable and blocked names are a
+// validation error on the subdomain field. Reserved and taken names are
+// reported unavailable, with free alternatives.
+func (s *SubdomainService) Check(ctx context.Context, name string) (*SubdomainAvailability, error) {
+	n, err := s.policy.Check(name)
+	reason := subdomain.ReasonOf(err)
+	switch {
+	case reason == subdomain.ReasonReserved:
+		// Reserved names are well formed, so alternatives can still be
+		// built from them
+		n, _ = subdomain.Parse(name)
+	case err != nil:
+		var refused *subdomain.Error
+		if stderrors.As(err, &refused) {
+			return nil, errors.Validation(refused.FieldError("subdomain"))
+		}
+		return nil, errors.InternalServer("failed to check subdomain", err)
+	}
+
+	result := &SubdomainAvailability{Subdomain: n.ASCII, Display: n.Unicode, Available: true}
+	if reason == "" {
+		taken, err := s.taken(ctx, []string{n.ASCII})
+		if err != nil {
+			return nil, err
+		}
+		if len(taken) > 0 {
+			reason = subdomain.ReasonTaken
+		}
+	}
+	if reason == "" {
+		return result, nil
+	}
+
+	result.Available = false
+	result.Reason = reason
+	candidates := s.policy.Suggestions(n, 2*suggestionCount)
+	taken, err := s.taken(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range candidates {
+		if len(result.Suggestions) < suggestionCount && !slices.Contains(taken, c) {
+			result.Suggestions = append(result.Suggestions, c)
+		}
+	}
+	return result, nil
+}
+
+func (s *SubdomainService) taken(ctx context.Context, candidates []string) ([]string, error) {
+	taken, err := s.repo.TakenSubdomains(ctx, candidates)
+	if err != nil {
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, errors.InternalServer("failed to check subdomain", err)
+	}
+	return taken, nil
+}
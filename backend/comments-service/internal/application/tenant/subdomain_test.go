@@ -0,0 +1,84 @@
+package tenant
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/subdomain"
+)
+
+// fakeSubdomains holds the registered subdomains and counts the names
+// looked up
+type fakeSubdomains struct {
+	registered []string
+	looked     int
+}
+
+func (f *fakeSubdomains) TakenSubdomains(ctx context.Context, candidates []string) ([]string, error) {
+	f.looked += len(candidates)
+	var taken []string
+	for _, c := range candidates {
+		if slices.Contains(f.registered, c) {
+			taken = append(taken, c)
+		}
+	}
+	return taken, nil
+}
+
+func TestSubdomainService_Check(t *testing.T) {
+	repo := &fakeSubdomains{registered: []string{"acme", "acme-hq", "xn--bcher-kva"}}
+	svc := NewSubdomainService(nil, repo)
+
+	tests := []struct {
+		name        string
+		in          string
+		subdomain   string
+		available   bool
+		reason      subdomain.Reason
+		suggestions []string
+		// looked is how many names reach the repository
+		looked int
+	}{
+		{name: "free", in: "Globex", subdomain: "globex", available: true, looked: 1},
+		{name: "taken", in: "acme", subdomain: "acme", reason: subdomain.ReasonTaken, suggestions: []string{"acme-app", "acme-team", "acme-community"}},
+		{name: "taken idn", in: "Bücher", subdomain: "xn--bcher-kva", reason: subdomain.ReasonTaken, suggestions: []string{"xn--bcher-hq-65a", "xn--bcher-app-q9a", "xn--bcher-team-9db"}},
+		{name: "reserved", in: "api", subdomain: "api", reason: subdomain.ReasonReserved, suggestions: []string{"api-hq", "api-app", "api-team"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.looked = 0
+			got, err := svc.Check(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Subdomain != tt.subdomain || got.Available != tt.available || got.Reason != tt.reason {
+				t.Errorf("expected %s available=%v %s, got %+v", tt.subdomain, tt.available, tt.reason, got)
+			}
+			if strings.Join(got.Suggestions, ",") != strings.Join(tt.suggestions, ",") {
+				t.Errorf("expected suggestions %v, got %v", tt.suggestions, got.Suggestions)
+			}
+			if tt.looked != 0 && repo.looked != tt.looked {
+				t.Errorf("expected %d names looked up, got %d", tt.looked, repo.looked)
+			}
+		})
+	}
+}
+
+func TestSubdomainService_CheckRejectsInvalidNames(t *testing.T) {
+	svc := NewSubdomainService(nil, &fakeSubdomains{})
+
+	for _, name := range []string{"a", "-acme", "acme_corp", "pаypal", "acme-porn"} {
+		_, err := svc.Check(context.Background(), name)
+		var appErr *errors.AppError
+		if !stderrors.As(err, &appErr) || appErr.StatusCode != http.StatusUnprocessableEntity ||
+			len(appErr.Errors) != 1 || appErr.Errors[0].Path != "subdomain" {
+			t.Errorf("expected a subdomain violation for %q, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnv names the YAML config file when no -config flag is given
+	ConfigFileEnv = "CONFIG_FILE"
+	// ConfigFileFlag is the flag naming the YAML config file
+	ConfigFileFlag = "config"
+)
+
+// dotEnvFile holds variables for local development. It is read on every
+// Load, not copied into the process environment, so a reload sees its edits.
+var dotEnvFile = ".env"
+
+// environment is the process environment over the variables of the .env
+// file: a variable set in the process, even to "", wins
+type environment map[string]string
+
+// readEnvironment reads the .env file; a missing file is no error
+func readEnvironment() (environment, error) {
+	vars, err := godotenv.Read(dotEnvFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return environment{}, nil
+	}
+	return vars, err
+}
+
+func (e environment) Get(key string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return e[key]
+}
+
+// Load builds the configuration from the defaults, the YAML config file, the
+// environment (and a .env file if present) and args, each overriding the
+// previous one. Secret settings read from the environment may instead come
+// from a *_FILE variable or the vault. args are command-line flags named after the file keys, as in
+// -server.port=8081. Every malformed or invalid setting is reported in one
+// *ValidationError; flag.ErrHelp is returned as is.
+func Load(args ...string) (*Config, error) {
+	config := &Config{}
+	l := &loader{fields: fields(config)}
+	env, err := readEnvironment()
+	if err != nil {
+		l.errorf(dotEnvFile, "cannot parse: %v", err)
+	}
+	l.env = env
+
+	flags, file, err := l.parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range l.fields {
+		if f.def != "" {
+			l.set(f, f.def, "default")
+		}
+	}
+	if file != "" {
+		l.loadFile(file)
+	}
+	for _, f := range l.fields {
+		if f.secret != "" {
+			continue
+		}
+		if value := l.env.Get(f.env); value != "" {
+			l.set(f, value, "$"+f.env)
+		}
+	}
+	for _, f := range l.fields {
+		if value, ok := flags[f.path]; ok {
+			l.set(f, value, "-"+f.path)
+		}
+	}
+	// Secrets take the env layer's place, now that the vault is configured
+	provider := newSecretProvider(config.Secrets, l.env)
+	for _, f := range l.fields {
+		if _, ok := flags[f.path]; ok || f.secret == "" {
+			continue
+		}
+		l.loadSecret(f, provider)
+	}
+
+	if config.Cursor.Secret == "" {
+		config.Cursor.Secret = config.JWT.Secret
+	}
+	// Unkeyed hashes only suit development, so production has to opt in
+	if config.App.Environment == EnvProduction && !l.explicit["api_keys.accept_legacy"] {
+		config.APIKeys.AcceptLegacy = false
+	}
+
+	l.errs = append(l.errs, config.validate()...)
+	if len(l.errs) > 0 {
+		return nil, &ValidationError{Errors: l.errs}
+	}
+	return config, nil
+}
+
+// field is one leaf setting of Config
+type field struct {
+	// path is the section.key name used in the file and by flags
+	path   string
+	env    string
+	def    string
+	secret string
+	reload bool
+	value  reflect.Value
+}
+
+// fields lists the settings of c in declaration order, addressable so the
+// loader can set them
+func fields(c *Config) []field {
+	var out []field
+	root := reflect.ValueOf(c).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Type().Field(i)
+		sv := root.Field(i)
+		for j := 0; j < sv.NumField(); j++ {
+			sf := sv.Type().Field(j)
+			out = append(out, field{
+				path:   section.Tag.Get("yaml") + "." + sf.Tag.Get("yaml"),
+				env:    sf.Tag.Get("env"),
+				def:    sf.Tag.Get("default"),
+				secret: sf.Tag.Get("secret"),
+				reload: sf.Tag.Get("reload") == "true",
+				value:  sv.Field(j),
+			})
+		}
+	}
+	return out
+}
+
+// loader records settings that fail to parse instead of silently falling
+// back to their defaults
+type loader struct {
+	fields []field
+	env    environment
+	errs   []FieldError
+	// explicit holds the paths of fields set by something other than
+	// their default
+	explicit map[string]bool
+}
+
+func (l *loader) errorf(key, format string, args ...any) {
+	l.errs = append(l.errs, FieldError{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// set parses raw into f; source names where raw came from for error messages
+func (l *loader) set(f field, raw, source string) {
+	if source != "default" {
+		if l.explicit == nil {
+			l.explicit = make(map[string]bool)
+		}
+		l.explicit[f.path] = true
+	}
+	v := f.value
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := ParseDuration(raw)
+		if err != nil {
+			l.errorf(f.env, "%q is not a duration (from %s)", raw, source)
+			return
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			l.errorf(f.env, "%q is not an integer (from %s)", raw, source)
+			return
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			l.errorf(f.env, "%q is not a boolean (from %s)", raw, source)
+			return
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice:
+		v.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		v.SetString(raw)
+	}
+}
+
+// loadSecret sets f from provider. A secret may be given directly or
+// through its _FILE variable, not both.
+func (l *loader) loadSecret(f field, provider SecretProvider) {
+	if l.env.Get(f.env) != "" && l.env.Get(f.env+FileSuffix) != "" {
+		l.errorf(f.env, "set either %s or %s, not both", f.env, f.env+FileSuffix)
+		return
+	}
+	value, ok, err := provider.Secret(f.env)
+	if err != nil {
+		l.errorf(f.env, "%v", err)
+		return
+	}
+	if ok {
+		l.set(f, value, "secret provider")
+	}
+}
+
+// splitList parses a comma-separated list, dropping empty items
+func splitList(raw string) []string {
+	var out []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// loadFile applies a YAML file of sections holding the same keys as Config.
+// Lists may be YAML sequences or comma-separated strings.
+func (l *loader) loadFile(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		l.errorf(ConfigFileEnv, "cannot read %s: %v", path, err)
+		return
+	}
+	var doc map[string]map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		l.errorf(ConfigFileEnv, "cannot parse %s: %v", path, err)
+		return
+	}
+
+	byPath := make(map[string]field, len(l.fields))
+	for _, f := range l.fields {
+		byPath[f.path] = f
+	}
+	for section, keys := range doc {
+		for key, node := range keys {
+			f, ok := byPath[section+"."+key]
+			if !ok {
+				l.errorf(section+"."+key, "unknown setting in %s", path)
+				continue
+			}
+			switch node.Kind {
+			case yaml.ScalarNode:
+				if node.Tag != "!!null" {
+					l.set(f, node.Value, path)
+				}
+			case yaml.SequenceNode:
+				items := make([]string, 0, len(node.Content))
+				for _, item := range node.Content {
+					items = append(items, item.Value)
+				}
+				l.set(f, strings.Join(items, ","), path)
+			default:
+				l.errorf(f.env, "must be a value or a list (from %s)", path)
+			}
+		}
+	}
+}
+
+// parseFlags reads args into values keyed by field path, plus the config
+// file named by the flag or $CONFIG_FILE
+func (l *loader) parseFlags(args []string) (map[string]string, string, error) {
+	values := make(map[string]string)
+	fs := flag.NewFlagSet("comments-service", flag.ContinueOnError)
+	file := fs.String(ConfigFileFlag, "", "YAML config file (default $"+ConfigFileEnv+")")
+	for _, f := range l.fields {
+		fs.Var(&flagValue{path: f.path, values: values, isBool: f.value.Kind() == reflect.Bool}, f.path, "overrides $"+f.env)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+	if fs.NArg() > 0 {
+		return nil, "", fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if *file == "" {
+		*file = l.env.Get(ConfigFileEnv)
+	}
+	return values, *file, nil
+}
+
+// flagValue stores the raw flag so it can be applied after the file and env
+type flagValue struct {
+	path   string
+	values map[string]string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil || v.values == nil {
+		return ""
+	}
+	return v.values[v.path]
+}
+
+func (v *flagValue) Set(raw string) error {
+	v.values[v.path] = raw
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
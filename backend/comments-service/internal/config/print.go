@@ -0,0 +1,91 @@
+package config
+
+import (
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces secret values in Print output
+const Redacted = "REDACTED"
+
+// Print writes c as a YAML config file with secrets redacted. Each key is
+// annotated with its environment variable.
+func (c *Config) Print(w io.Writer) error {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	var section *yaml.Node
+	prefix := ""
+	for _, f := range fields(c) {
+		name, key, _ := strings.Cut(f.path, ".")
+		if name != prefix {
+			prefix = name
+			section = &yaml.Node{Kind: yaml.MappingNode}
+			doc.Content = append(doc.Content, scalar(name), section)
+		}
+		value := printValue(f)
+		value.LineComment = f.env
+		section.Content = append(section.Content, scalar(key), value)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func printValue(f field) *yaml.Node {
+	v := f.value
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		return scalar(formatDuration(time.Duration(v.Int())))
+	case v.Kind() == reflect.Int:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(v.Int(), 10)}
+	case v.Kind() == reflect.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v.Bool())}
+	case v.Kind() == reflect.Slice:
+		list := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for i := 0; i < v.Len(); i++ {
+			list.Content = append(list.Content, scalar(v.Index(i).String()))
+		}
+		return list
+	}
+
+	s := v.String()
+	switch {
+	case s == "":
+	case f.secret == "url":
+		// Keep the location, hide the credentials
+		if u, err := url.Parse(s); err == nil {
+			s = u.Redacted()
+		} else {
+			s = Redacted
+		}
+	case f.secret != "":
+		s = Redacted
+	}
+	return scalar(s)
+}
+
+// formatDuration drops the zero units time.Duration.String pads with, so
+// 168h0m0s prints as 168h
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
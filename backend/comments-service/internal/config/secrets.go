@@ -0,0 +1,263 @@
+package config
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileSuffix turns a secret's env key into the variable naming a file that
+// holds it, as in DB_PASSWORD_FILE
+const FileSuffix = "_FILE"
+
+// SecretProvider looks up secret settings by their env key
+type SecretProvider interface {
+	// Secret returns the value of key, or ok false if the provider has none
+	Secret(key string) (value string, ok bool, err error)
+}
+
+// EnvProvider reads secrets from environment variables, then from the .env
+// file as read by NewSecretProvider
+type EnvProvider struct {
+	env environment
+}
+
+func (p EnvProvider) Secret(key string) (string, bool, error) {
+	value := p.env.Get(key)
+	return value, value != "", nil
+}
+
+// FileProvider reads the file named by the key's _FILE variable, as mounted
+// by Docker and Kubernetes secrets. Trailing newlines are trimmed.
+type FileProvider struct {
+	env environment
+}
+
+func (p FileProvider) Secret(key string) (string, bool, error) {
+	path := p.env.Get(key + FileSuffix)
+	if path == "" {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("read %s: %w", key+FileSuffix, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// VaultProvider reads secrets from a local file holding a JSON object of env
+// keys to values, sealed with AES-256-GCM (see SealVault). KeyFile holds the
+// base64 encoded 32 byte key. Both are read on every lookup so a replaced
+// vault or key applies at the next refresh.
+type VaultProvider struct {
+	Path    string
+	KeyFile string
+}
+
+func (p VaultProvider) Secret(key string) (string, bool, error) {
+	secrets, err := p.open()
+	if err != nil {
+		return "", false, err
+	}
+	value, ok := secrets[key]
+	return value, ok, nil
+}
+
+func (p VaultProvider) open() (map[string]string, error) {
+	key, err := ReadVaultKey(p.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read vault: %w", err)
+	}
+	return OpenVault(key, sealed)
+}
+
+// ReadVaultKey reads a base64 encoded vault key from path
+func ReadVaultKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read vault key: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != 32 {
+		return nil, stderrors.New("vault key must be 32 bytes, base64 encoded")
+	}
+	return key, nil
+}
+
+// SealVault encrypts secrets with key into the vault file format: a random
+// nonce followed by the AES-256-GCM sealed JSON
+func SealVault(key []byte, secrets map[string]string) ([]byte, error) {
+	gcm, err := vaultCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+// OpenVault decrypts a vault sealed by SealVault
+func OpenVault(key, sealed []byte) (map[string]string, error) {
+	gcm, err := vaultCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, stderrors.New("vault is truncated")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, stderrors.New("vault cannot be decrypted with this key")
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("decode vault: %w", err)
+	}
+	return secrets, nil
+}
+
+func vaultCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ChainProvider asks each provider in turn and returns the first value found
+type ChainProvider []SecretProvider
+
+func (c ChainProvider) Secret(key string) (string, bool, error) {
+	for _, p := range c {
+		value, ok, err := p.Secret(key)
+		if err != nil || ok {
+			return value, ok, err
+		}
+	}
+	return "", false, nil
+}
+
+// NewSecretProvider returns the providers configured by cfg: the environment,
+// *_FILE variables and, if SECRETS_VAULT_FILE is set, the vault
+func NewSecretProvider(cfg SecretsConfig) SecretProvider {
+	// A .env that cannot be parsed already failed Load
+	env, _ := readEnvironment()
+	return newSecretProvider(cfg, env)
+}
+
+func newSecretProvider(cfg SecretsConfig, env environment) SecretProvider {
+	chain := ChainProvider{EnvProvider{env}, FileProvider{env}}
+	if cfg.VaultFile != "" {
+		chain = append(chain, VaultProvider{Path: cfg.VaultFile, KeyFile: cfg.VaultKeyFile})
+	}
+	return chain
+}
+
+// Secrets holds the current value of every secret setting. Refresh re-reads
+// them, and the .env file, so rotated secrets apply without a restart.
+type Secrets struct {
+	config SecretsConfig
+	keys   []string
+
+	mu        sync.RWMutex
+	values    map[string]string
+	listeners map[string][]func(string)
+}
+
+// NewSecrets starts from the secrets loaded into cfg
+func NewSecrets(cfg *Config) *Secrets {
+	s := &Secrets{
+		config:    cfg.Secrets,
+		values:    make(map[string]string),
+		listeners: make(map[string][]func(string)),
+	}
+	for _, f := range fields(cfg) {
+		if f.secret != "" {
+			s.keys = append(s.keys, f.env)
+			s.values[f.env] = f.value.String()
+		}
+	}
+	return s
+}
+
+// Get returns the current value of the secret with env key
+func (s *Secrets) Get(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[key]
+}
+
+// OnChange registers fn to be called with the new value whenever key rotates
+func (s *Secrets) OnChange(key string, fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners[key] = append(s.listeners[key], fn)
+}
+
+// Refresh re-reads every secret. Secrets that cannot be read keep their
+// previous value; their errors are returned together.
+func (s *Secrets) Refresh() error {
+	var errs []error
+	provider := NewSecretProvider(s.config)
+	for _, key := range s.keys {
+		value, ok, err := provider.Secret(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		s.mu.Lock()
+		changed := s.values[key] != value
+		s.values[key] = value
+		listeners := s.listeners[key]
+		s.mu.Unlock()
+
+		if changed {
+			log.Printf("Secret %s rotated", key)
+			for _, fn := range listeners {
+				fn(value)
+			}
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// Watch refreshes the secrets every interval until ctx is done
+func (s *Secrets) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(); err != nil {
+				log.Printf("Failed to refresh secrets: %v", err)
+			}
+		}
+	}
+}
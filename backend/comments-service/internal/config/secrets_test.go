@@ -0,0 +1,125 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeVault(t *testing.T, key []byte, secrets map[string]string) string {
+	t.Helper()
+	sealed, err := SealVault(key, secrets)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	return writeFile(t, "vault", string(sealed))
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "from-file\n"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Database.Password != "from-file" {
+		t.Errorf("Expected password from file, got %q", cfg.Database.Password)
+	}
+
+	t.Setenv("DB_PASSWORD", "from-env")
+	_, err = Load()
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Errors[0].Key != "DB_PASSWORD" {
+		t.Errorf("Expected DB_PASSWORD and DB_PASSWORD_FILE to conflict, got %v", err)
+	}
+
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := Load(); !errors.As(err, &verr) {
+		t.Errorf("Expected a missing secret file to fail, got %v", err)
+	}
+}
+
+func TestLoad_Vault(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	t.Setenv("SECRETS_VAULT_KEY_FILE", writeFile(t, "key", base64.StdEncoding.EncodeToString(key)))
+	t.Setenv("SECRETS_VAULT_FILE", writeVault(t, key, map[string]string{"JWT_SECRET": "from-vault"}))
+	t.Setenv("REDIS_PASSWORD", "from-env")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.JWT.Secret != "from-vault" || cfg.Cursor.Secret != "from-vault" {
+		t.Errorf("Expected JWT and cursor secrets from the vault, got %q and %q", cfg.JWT.Secret, cfg.Cursor.Secret)
+	}
+	if cfg.Redis.Password != "from-env" {
+		t.Errorf("Expected env to win over the vault, got %q", cfg.Redis.Password)
+	}
+
+	t.Setenv("SECRETS_VAULT_KEY_FILE", writeFile(t, "key", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{8}, 32))))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "cannot be decrypted") {
+		t.Errorf("Expected wrong vault key to fail, got %v", err)
+	}
+}
+
+func TestOpenVault_RejectsTampering(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	sealed, _ := SealVault(key, map[string]string{"DB_PASSWORD": "x"})
+	sealed[len(sealed)-1] ^= 1
+
+	if _, err := OpenVault(key, sealed); err == nil {
+		t.Error("Expected modified vault to be rejected")
+	}
+	if _, err := OpenVault(key, sealed[:4]); err == nil {
+		t.Error("Expected truncated vault to be rejected")
+	}
+}
+
+func TestSecrets_Refresh(t *testing.T) {
+	file := writeFile(t, "db_password", "first")
+	t.Setenv("DB_PASSWORD_FILE", file)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	secrets := NewSecrets(cfg)
+
+	var rotated []string
+	secrets.OnChange("DB_PASSWORD", func(value string) { rotated = append(rotated, value) })
+
+	if err := secrets.Refresh(); err != nil || len(rotated) != 0 {
+		t.Fatalf("Expected no rotation, got %v, %v", rotated, err)
+	}
+
+	os.WriteFile(file, []byte("second"), 0o600)
+	if err := secrets.Refresh(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secrets.Get("DB_PASSWORD") != "second" || len(rotated) != 1 || rotated[0] != "second" {
+		t.Errorf("Expected rotation to second, got %q and %v", secrets.Get("DB_PASSWORD"), rotated)
+	}
+
+	// An unreadable secret keeps its last value
+	os.Remove(file)
+	if err := secrets.Refresh(); err == nil {
+		t.Error("Expected error for a missing secret file")
+	}
+	if secrets.Get("DB_PASSWORD") != "second" {
+		t.Errorf("Expected last value to be kept, got %q", secrets.Get("DB_PASSWORD"))
+	}
+}
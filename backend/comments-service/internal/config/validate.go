@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/pkg/apikey"
+)
+
+const (
+	// EnvProduction is the APP_ENV that enables the production safety checks
+	EnvProduction = "production"
+
+	defaultJWTSecret = "change-this-secret"
+	// minSecretLength is the shortest signing secret accepted in production
+	minSecretLength = 32
+)
+
+// insecureSecrets are placeholder secrets shipped with the repo
+var insecureSecrets = map[string]bool{
+	defaultJWTSecret: true,
+	"your-super-secret-jwt-key-change-this-in-production": true,
+}
+
+// FieldError is a problem with one setting, named by its env var
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// ValidationError lists every invalid setting found by Load
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration (%d errors):", len(e.Errors))
+	for _, fe := range e.Errors {
+		b.WriteString("\n  - ")
+		b.WriteString(fe.Error())
+	}
+	return b.String()
+}
+
+// Validate checks c for values the service cannot run with. In production it
+// also refuses insecure defaults. The error is a *ValidationError.
+func (c *Config) Validate() error {
+	if errs := c.validate(); len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func (c *Config) validate() []FieldError {
+	v := &validator{}
+
+	v.oneOf("APP_ENV", c.App.Environment, "development", "test", "staging", EnvProduction)
+	v.oneOf("LOG_LEVEL", c.App.LogLevel, "debug", "info", "warn", "error")
+	v.oneOf("LOG_FORMAT", c.App.LogFormat, "json", "text")
+
+	v.port("PORT", c.Server.Port)
+	v.positive("SERVER_READ_TIMEOUT", c.Server.ReadTimeout)
+	v.positive("SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout)
+	v.positive("SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	v.positive("SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout)
+	v.positive("SERVER_SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout)
+	v.min("SERVER_MAX_HEADER_BYTES", c.Server.MaxHeaderBytes, 1)
+	v.min("SERVER_MAX_BODY_BYTES", c.Server.MaxBodyBytes, 0)
+	if c.Server.GRPCPort != 0 {
+		v.port("SERVER_GRPC_PORT", c.Server.GRPCPort)
+		if c.Server.GRPCPort == c.Server.Port {
+			v.add("SERVER_GRPC_PORT", "must differ from PORT")
+		}
+	}
+	v.min("SERVER_GRPC_MAX_RECV_BYTES", c.Server.GRPCMaxRecvBytes, 1)
+
+	v.required("DB_HOST", c.Database.Host)
+	v.port("DB_PORT", c.Database.Port)
+	v.required("DB_USER", c.Database.User)
+	v.required("DB_NAME", c.Database.DBName)
+	v.oneOf("DB_SSLMODE", c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	v.min("DB_MAX_CONNECTIONS", c.Database.MaxConnections, 1)
+	v.min("DB_MAX_IDLE_CONNECTIONS", c.Database.MaxIdleConns, 0)
+	if c.Database.MaxIdleConns > c.Database.MaxConnections {
+		v.add("DB_MAX_IDLE_CONNECTIONS", "must not exceed DB_MAX_CONNECTIONS")
+	}
+	if c.Database.ReadHost != "" {
+		v.port("DB_READ_PORT", c.Database.ReadPort)
+	}
+
+	if c.Redis.ClusterEnabled {
+		if len(c.Redis.ClusterAddrs) == 0 {
+			v.add("REDIS_CLUSTER_ADDRS", "is required when REDIS_CLUSTER_ENABLED is true")
+		}
+		if c.Redis.DB != 0 {
+			v.add("REDIS_DB", "must be 0 in cluster mode")
+		}
+	} else {
+		v.required("REDIS_HOST", c.Redis.Host)
+		v.port("REDIS_PORT", c.Redis.Port)
+		v.min("REDIS_DB", c.Redis.DB, 0)
+	}
+
+	v.required("RABBITMQ_URL", c.RabbitMQ.URL)
+
+	v.required("JWT_SECRET", c.JWT.Secret)
+	v.positive("JWT_EXPIRY", c.JWT.Expiry)
+	v.positive("JWT_REFRESH_EXPIRY", c.JWT.RefreshExpiry)
+	if c.JWT.RefreshExpiry > 0 && c.JWT.RefreshExpiry < c.JWT.Expiry {
+		v.add("JWT_REFRESH_EXPIRY", "must not be shorter than JWT_EXPIRY")
+	}
+	v.required("CURSOR_SECRET", c.Cursor.Secret)
+	// Unkeyed hashes alone would let a leaked database confirm guessed keys
+	v.peppers("API_KEY_PEPPERS", c.APIKeys.Peppers, c.APIKeys.AcceptLegacy, c.App.Environment == EnvProduction)
+
+	v.positive("HEALTH_PROBE_TIMEOUT", c.Health.ProbeTimeout)
+
+	v.min("REALTIME_REPLAY_LIMIT", c.Realtime.ReplayLimit, 0)
+	v.positive("REALTIME_REPLAY_TTL", c.Realtime.ReplayTTL)
+	v.positive("REALTIME_HEARTBEAT", c.Realtime.Heartbeat)
+	v.positive("REALTIME_PRESENCE_TTL", c.Realtime.PresenceTTL)
+	v.positive("REALTIME_TYPING_TTL", c.Realtime.TypingTTL)
+	v.min("REALTIME_MAX_SUBSCRIPTIONS", c.Realtime.MaxSubscriptions, 1)
+
+	v.positive("IDEMPOTENCY_TTL", c.Idempotency.TTL)
+	v.positive("IDEMPOTENCY_LOCK_TTL", c.Idempotency.LockTTL)
+
+	v.positive("TENANT_SETTINGS_CACHE_TTL", c.Tenants.SettingsCacheTTL)
+
+	if c.RateLimit.Enabled {
+		v.min("DEFAULT_RATE_LIMIT_PER_MINUTE", c.RateLimit.PerMinute, 1)
+		v.min("DEFAULT_RATE_LIMIT_PER_HOUR", c.RateLimit.PerHour, c.RateLimit.PerMinute)
+	}
+
+	for _, m := range c.CORS.AllowedMethods {
+		v.oneOf("CORS_ALLOWED_METHODS", m, "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS")
+	}
+
+	if c.Metrics.Enabled {
+		v.port("METRICS_PORT", c.Metrics.Port)
+		if c.Metrics.Port == c.Server.Port || c.Metrics.Port == c.Server.GRPCPort {
+			v.add("METRICS_PORT", "must differ from PORT and SERVER_GRPC_PORT")
+		}
+	}
+	if c.Tracing.Enabled {
+		v.required("JAEGER_ENDPOINT", c.Tracing.JaegerEndpoint)
+	}
+
+	if c.Secrets.VaultFile != "" {
+		v.required("SECRETS_VAULT_KEY_FILE", c.Secrets.VaultKeyFile)
+	}
+	if c.Secrets.RefreshInterval < 0 {
+		v.add("SECRETS_REFRESH_INTERVAL", "must not be negative")
+	}
+
+	if c.App.Environment == EnvProduction {
+		v.secret("JWT_SECRET", c.JWT.Secret)
+		v.secret("CURSOR_SECRET", c.Cursor.Secret)
+		if c.Database.SSLMode == "disable" || c.Database.SSLMode == "allow" {
+			v.add("DB_SSLMODE", fmt.Sprintf("%q does not require TLS, which is refused in production", c.Database.SSLMode))
+		}
+		if c.Database.Password == "" || c.Database.Password == "postgres" {
+			v.add("DB_PASSWORD", "must be set to a non-default password in production")
+		}
+	}
+
+	return v.errs
+}
+
+type validator struct {
+	errs []FieldError
+}
+
+func (v *validator) add(key, message string) {
+	v.errs = append(v.errs, FieldError{Key: key, Message: message})
+}
+
+func (v *validator) required(key, value string) {
+	if value == "" {
+		v.add(key, "is required")
+	}
+}
+
+func (v *validator) port(key string, port int) {
+	if port < 1 || port > 65535 {
+		v.add(key, fmt.Sprintf("%d is not a valid port", port))
+	}
+}
+
+func (v *validator) min(key string, value, min int) {
+	if value < min {
+		v.add(key, fmt.Sprintf("must be at least %d, got %d", min, value))
+	}
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	if d <= 0 {
+		v.add(key, fmt.Sprintf("must be positive, got %s", d))
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(key, fmt.Sprintf("%q must be one of %s", value, strings.Join(allowed, ", ")))
+}
+
+// peppers checks an API key pepper list the way apikey.NewHasher will. A
+// list is needed when required or when legacy hashes are not accepted. The
+// secrets are never echoed.
+func (v *validator) peppers(key, value string, acceptLegacy, required bool) {
+	if value == "" && (required || !acceptLegacy) {
+		v.required(key, value)
+		return
+	}
+	peppers, err := apikey.ParsePeppers(value)
+	if err == nil {
+		_, err = apikey.NewHasher(peppers, acceptLegacy)
+	}
+	if err != nil {
+		v.add(key, strings.TrimPrefix(err.Error(), "apikey: "))
+	}
+}
+
+// secret refuses placeholder and short secrets; the value is never echoed
+func (v *validator) secret(key, value string) {
+	switch {
+	case insecureSecrets[value]:
+		v.add(key, "is a placeholder and must be replaced in production")
+	case len(value) < minSecretLength:
+		v.add(key, fmt.Sprintf("must be at least %d characters in production", minSecretLength))
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Watcher holds the running configuration. Reload loads every source again
+// and swaps in a snapshot with the new values of the settings tagged
+// reload:"true". Other settings need a restart: their changes are logged and
+// ignored. Secrets are refreshed by Secrets instead.
+type Watcher struct {
+	args    []string
+	current atomic.Pointer[Config]
+
+	// mu serializes reloads and guards subs
+	mu   sync.Mutex
+	subs []func(old, next *Config)
+}
+
+// NewWatcher starts from cfg, which was loaded from args
+func NewWatcher(cfg *Config, args ...string) *Watcher {
+	w := &Watcher{args: args}
+	w.current.Store(cfg)
+	return w
+}
+
+// Current returns the latest snapshot. Snapshots are never modified, so
+// callers may keep one for the duration of a request.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Subscribe registers fn to be called after each reload that changed
+// something
+func (w *Watcher) Subscribe(fn func(old, next *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs = append(w.subs, fn)
+}
+
+// Reload loads and validates the configuration, then applies the reloadable
+// changes. It returns the env keys that changed; on error nothing changes.
+func (w *Watcher) Reload() ([]string, error) {
+	loaded, err := Load(w.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	old := w.current.Load()
+	next := *old
+	nextFields, loadedFields := fields(&next), fields(loaded)
+
+	var changed []string
+	for i, f := range nextFields {
+		value := loadedFields[i].value
+		if f.secret != "" || reflect.DeepEqual(f.value.Interface(), value.Interface()) {
+			continue
+		}
+		if !f.reload {
+			log.Printf("Config %s changed but only applies after a restart; keeping the running value", f.env)
+			continue
+		}
+		f.value.Set(value)
+		changed = append(changed, f.env)
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	w.current.Store(&next)
+	for _, fn := range w.subs {
+		fn(old, &next)
+	}
+	return changed, nil
+}
+
+// Run reloads on SIGHUP and, when a config file is used, whenever its
+// modification time changes, checked every poll, until ctx is done
+func (w *Watcher) Run(ctx context.Context, poll time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	file := w.configFile()
+	var tick <-chan time.Time
+	if file != "" && poll > 0 {
+		ticker := time.NewTicker(poll)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	modified := modTime(file)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Println("SIGHUP received, reloading config")
+		case <-tick:
+			m := modTime(file)
+			if m.Equal(modified) {
+				continue
+			}
+			modified = m
+			log.Printf("Config file %s changed, reloading", file)
+		}
+
+		changed, err := w.Reload()
+		switch {
+		case err != nil:
+			log.Printf("Config reload rejected, keeping the running config: %v", err)
+		case len(changed) > 0:
+			log.Printf("Config reloaded: %v", changed)
+		}
+	}
+}
+
+// configFile names the config file as Load finds it, from the flag,
+// $CONFIG_FILE or the .env file
+func (w *Watcher) configFile() string {
+	env, err := readEnvironment()
+	if err != nil {
+		log.Printf("Cannot parse %s, looking for the config file without it: %v", dotEnvFile, err)
+	}
+	l := &loader{fields: fields(&Config{}), env: env}
+	_, file, _ := l.parseFlags(w.args)
+	return file
+}
+
+func modTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
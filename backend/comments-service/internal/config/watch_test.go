@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWatcher_Reload(t *testing.T) {
+	file := writeFile(t, "config.yaml", "app:\n  log_level: info\ndatabase:\n  host: db-1\n")
+
+	cfg, err := Load("-config", file)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	w := NewWatcher(cfg, "-config", file)
+
+	var notified int
+	w.Subscribe(func(old, next *Config) {
+		notified++
+		if old.App.LogLevel != "info" || next.App.LogLevel != "debug" {
+			t.Errorf("Expected info -> debug, got %s -> %s", old.App.LogLevel, next.App.LogLevel)
+		}
+	})
+
+	os.WriteFile(file, []byte("app:\n  log_level: debug\ndatabase:\n  host: db-2\ncors:\n  allowed_origins: [https://new.example]\n"), 0o600)
+	changed, err := w.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(changed, ",") != "LOG_LEVEL,CORS_ALLOWED_ORIGINS" {
+		t.Errorf("Expected LOG_LEVEL and CORS_ALLOWED_ORIGINS to change, got %v", changed)
+	}
+	current := w.Current()
+	if current.App.LogLevel != "debug" || current.CORS.AllowedOrigins[0] != "https://new.example" {
+		t.Errorf("Expected reloadable settings to apply, got %+v %+v", current.App, current.CORS)
+	}
+	if current.Database.Host != "db-1" {
+		t.Errorf("Expected structural setting to be kept, got %s", current.Database.Host)
+	}
+	if cfg.App.LogLevel != "info" {
+		t.Error("Expected the previous snapshot to stay unchanged")
+	}
+	if notified != 1 {
+		t.Errorf("Expected 1 notification, got %d", notified)
+	}
+
+	// Invalid configs are rejected as a whole
+	os.WriteFile(file, []byte("app:\n  log_level: loud\n"), 0o600)
+	if _, err := w.Reload(); err == nil {
+		t.Error("Expected invalid config to be rejected")
+	}
+	if w.Current() != current || notified != 1 {
+		t.Error("Expected rejected reload to keep the running config")
+	}
+
+	// Nothing reloadable changed
+	os.WriteFile(file, []byte("app:\n  log_level: debug\ndatabase:\n  host: db-3\ncors:\n  allowed_origins: [https://new.example]\n"), 0o600)
+	if changed, err := w.Reload(); err != nil || changed != nil || notified != 1 {
+		t.Errorf("Expected no change, got %v, %v", changed, err)
+	}
+}
+
+func TestWatcher_ReloadDotEnv(t *testing.T) {
+	dotEnv := writeFile(t, ".env", "LOG_LEVEL=info\nCORS_ALLOWED_ORIGINS=https://dotenv.example\n")
+	defer func(prev string) { dotEnvFile = prev }(dotEnvFile)
+	dotEnvFile = dotEnv
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://env.example")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.App.LogLevel != "info" || cfg.CORS.AllowedOrigins[0] != "https://env.example" {
+		t.Errorf("Expected .env under the environment, got %s %v", cfg.App.LogLevel, cfg.CORS.AllowedOrigins)
+	}
+	w := NewWatcher(cfg)
+
+	os.WriteFile(dotEnv, []byte("LOG_LEVEL=debug\nCORS_ALLOWED_ORIGINS=https://edited.example\n"), 0o600)
+	changed, err := w.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(changed, ",") != "LOG_LEVEL" {
+		t.Errorf("Expected only LOG_LEVEL to change, got %v", changed)
+	}
+
+	os.WriteFile(dotEnv, []byte("LOG_LEVEL='debug\n"), 0o600)
+	if _, err := w.Reload(); err == nil {
+		t.Error("Expected a malformed .env to be rejected")
+	}
+}
+
+func TestWatcher_ConfigFileFromDotEnv(t *testing.T) {
+	file := writeFile(t, "config.yaml", "app:\n  log_level: info\n")
+	dotEnv := writeFile(t, ".env", "CONFIG_FILE="+file+"\n")
+	defer func(prev string) { dotEnvFile = prev }(dotEnvFile)
+	dotEnvFile = dotEnv
+	t.Setenv(ConfigFileEnv, "")
+	os.Unsetenv(ConfigFileEnv)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if got := NewWatcher(cfg).configFile(); got != file {
+		t.Errorf("Expected the watcher to poll %s, got %q", file, got)
+	}
+}
@@ -0,0 +1,151 @@
+// Package comment holds the comment entity and the repository contract
+// implemented by the persistence layer.
+package comment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+)
+
+// Status mirrors the comment_status enum
+type Status string
+
+const (
+	StatusActive  Status = "ACTIVE"
+	StatusDeleted Status = "DELETED"
+	StatusFlagged Status = "FLAGGED"
+	StatusSpam    Status = "SPAM"
+	StatusPending Status = "PENDING"
+)
+
+// Format mirrors the content_format enum
+type Format string
+
+const (
+	FormatPlain    Format = "plain"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// IsValid reports whether f is a known content format
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatPlain, FormatMarkdown, FormatHTML:
+		return true
+	}
+	return false
+}
+
+// Limits enforced by the comments table CHECK constraints
+const (
+	MinContentLength = 1
+	MaxContentLength = 10000
+	MaxDepth         = 100
+)
+
+// Comment is a single node in a thread
+type Comment struct {
+	ID            string
+	TenantID      string
+	ParentID      *string
+	Depth         int
+	Path          string
+	EntityType    string
+	EntityID      string
+	AuthorID      string
+	AuthorName    string
+	AuthorEmail   *string
+	Content       string
+	ContentFormat Format
+	Status        Status
+	IsPinned      bool
+	IsEdited      bool
+	LikeCount     int
+	ReplyCount    int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	EditedAt      *time.Time
+}
+
+// ChildPath returns the materialized path stored on direct replies to c,
+// matching the LIKE patterns used by get_comment_tree
+func (c *Comment) ChildPath() string {
+	return c.Path + "." + c.ID
+}
+
+// Sort selects the ordering of a listing
+type Sort string
+
+const (
+	SortNewest Sort = "new"
+	SortOldest Sort = "old"
+	SortTop    Sort = "top"
+)
+
+// IsValid reports whether s is a known sort order
+func (s Sort) IsValid() bool {
+	switch s {
+	case SortNewest, SortOldest, SortTop:
+		return true
+	}
+	return false
+}
+
+// ListQuery selects the comments under one entity. A nil ParentID lists
+// root comments only.
+type ListQuery struct {
+	TenantID   string
+	EntityType string
+	EntityID   string
+	ParentID   *string
+	Sort       Sort
+	// After resumes from a previous page; nil starts at the top
+	After *cursor.Cursor
+	Limit int
+}
+
+// CursorFor returns the position of c in a listing ordered by s
+func (c *Comment) CursorFor(s Sort) cursor.Cursor {
+	cur := cursor.Cursor{
+		Order:     string(s),
+		Pinned:    c.IsPinned,
+		CreatedAt: c.CreatedAt,
+		ID:        c.ID,
+	}
+	if s == SortTop {
+		cur.Score = int64(c.LikeCount)
+	}
+	return cur
+}
+
+// Edit describes a content change recorded in comment_edits
+type Edit struct {
+	Content  string
+	EditedBy string
+	Reason   *string
+}
+
+// ErrNotFound is returned when a comment does not exist, belongs to another
+// tenant or has been soft-deleted
+var ErrNotFound = errors.New("comment not found")
+
+// Repository persists comments. Every method is scoped to a tenant.
+type Repository interface {
+	Create(ctx context.Context, c *Comment) error
+	GetByID(ctx context.Context, tenantID, id string) (*Comment, error)
+	Update(ctx context.Context, tenantID, id string, edit Edit) (*Comment, error)
+	SoftDeleteTree(ctx context.Context, tenantID, id string) (int, error)
+	// List returns up to q.Limit comments in display order, starting after
+	// (or ending before, for a backward cursor) q.After
+	List(ctx context.Context, q ListQuery) ([]*Comment, error)
+	// Descendants returns the live replies below rootIDs down to the
+	// absolute depth maxDepth, keeping at most perParent per parent
+	Descendants(ctx context.Context, tenantID string, rootIDs []string, maxDepth, perParent int, sort Sort) ([]*Comment, error)
+	// ReplyCounts returns how many live replies List and Descendants would
+	// find under each of parentIDs. Unlike ReplyCount it leaves out replies
+	// held for moderation; parents without live replies are left out.
+	ReplyCounts(ctx context.Context, tenantID string, parentIDs []string) (map[string]int, error)
+}
@@ -0,0 +1,59 @@
+package comment
+
+import (
+	"context"
+	"time"
+)
+
+// EventType names a change to the comments of an entity
+type EventType string
+
+const (
+	EventCreated EventType = "comment.created"
+	EventEdited  EventType = "comment.edited"
+	EventDeleted EventType = "comment.deleted"
+	// EventReactions reports a new like count for a comment
+	EventReactions EventType = "comment.reactions"
+)
+
+// Event is a change published to the live streams of an entity. ID is
+// assigned by the stream when the event is published and orders the events
+// of one entity.
+type Event struct {
+	ID         string    `json:"id,omitempty"`
+	Type       EventType `json:"type"`
+	TenantID   string    `json:"tenant_id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	CommentID  string    `json:"comment_id"`
+	// Comment is set for created and edited events
+	Comment *Comment `json:"comment,omitempty"`
+	// Deleted counts the comments removed with CommentID
+	Deleted    int       `json:"deleted,omitempty"`
+	LikeCount  int       `json:"like_count,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NewEvent builds an event about c
+func NewEvent(t EventType, c *Comment) Event {
+	return Event{
+		Type:       t,
+		TenantID:   c.TenantID,
+		EntityType: c.EntityType,
+		EntityID:   c.EntityID,
+		CommentID:  c.ID,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// EventPublisher fans events out to every subscriber of the entity
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// EventSubscriber streams the events of one entity. With a lastEventID the
+// events published after it are replayed first. The channel is closed when
+// ctx ends or the subscriber falls too far behind.
+type EventSubscriber interface {
+	Subscribe(ctx context.Context, tenantID, entityType, entityID, lastEventID string) (<-chan Event, error)
+}
@@ -0,0 +1,32 @@
+// Package idempotency models the stored outcome of a request made with an
+// Idempotency-Key so retries can be answered without repeating it.
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Record is the state of one tenant-scoped key. Fingerprint identifies the
+// request that first used the key; the response fields are set once it
+// completed.
+type Record struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Store keeps records per tenant and key
+type Store interface {
+	// Reserve claims key for a request with fingerprint for lockTTL. It
+	// returns nil when the claim succeeded and the existing record when the
+	// key is already taken.
+	Reserve(ctx context.Context, tenantID, key, fingerprint string, lockTTL time.Duration) (*Record, error)
+	// Complete stores the response of the request holding the claim
+	Complete(ctx context.Context, tenantID, key string, record Record, ttl time.Duration) error
+	// Release drops an unfinished claim so the request can be retried
+	Release(ctx context.Context, tenantID, key string) error
+}
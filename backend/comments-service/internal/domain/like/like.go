@@ -0,0 +1,40 @@
+// Package like holds the reactions users give comments and the repository
+// contract implemented by the persistence layer.
+package like
+
+import "context"
+
+// Reaction mirrors the reaction_type enum
+type Reaction string
+
+const (
+	ReactionLike  Reaction = "LIKE"
+	ReactionLove  Reaction = "LOVE"
+	ReactionLaugh Reaction = "LAUGH"
+	ReactionWow   Reaction = "WOW"
+	ReactionSad   Reaction = "SAD"
+	ReactionAngry Reaction = "ANGRY"
+)
+
+// Reactions lists every reaction in enum order
+var Reactions = []Reaction{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry}
+
+// IsValid reports whether r is a known reaction
+func (r Reaction) IsValid() bool {
+	switch r {
+	case ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry:
+		return true
+	}
+	return false
+}
+
+// Repository stores likes. A user has at most one reaction per comment, and
+// every reaction counts once towards the comment's like_count. Both methods
+// return the like count after the call and whether the call changed it.
+type Repository interface {
+	// Add records the reaction of userID to a comment, replacing the one
+	// the user gave before
+	Add(ctx context.Context, tenantID, commentID, userID string, reaction Reaction) (count int, changed bool, err error)
+	// Remove deletes the reaction of userID to a comment, if there is one
+	Remove(ctx context.Context, tenantID, commentID, userID string) (count int, changed bool, err error)
+}
@@ -0,0 +1,68 @@
+// Package moderation models the review of comments that were reported or
+// held back: the queue of comments awaiting review and the decisions taken
+// on them.
+package moderation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+)
+
+// ErrNotQueued is returned when a decision is taken on a comment that is
+// not awaiting review
+var ErrNotQueued = errors.New("comment is not awaiting moderation")
+
+// Decision is a moderator's verdict on a comment awaiting review
+type Decision string
+
+const (
+	// DecisionApprove publishes the comment again
+	DecisionApprove Decision = "APPROVE"
+	// DecisionReject keeps the comment hidden as spam
+	DecisionReject Decision = "REJECT"
+)
+
+// IsValid reports whether d is a known decision
+func (d Decision) IsValid() bool {
+	return d == DecisionApprove || d == DecisionReject
+}
+
+// Status is the comment status a decision leads to
+func (d Decision) Status() comment.Status {
+	if d == DecisionApprove {
+		return comment.StatusActive
+	}
+	return comment.StatusSpam
+}
+
+// Item is a comment with its moderation record
+type Item struct {
+	Comment        *comment.Comment
+	FlaggedCount   int
+	ModeratedBy    *string
+	ModerationNote *string
+}
+
+// Review records a decision on a comment
+type Review struct {
+	Decision    Decision
+	ModeratorID string
+	Note        *string
+}
+
+// Repository stores the moderation state of comments. Comments awaiting
+// review are the FLAGGED and PENDING ones; lists only show ACTIVE comments,
+// so a comment in the queue is hidden until it is approved.
+type Repository interface {
+	// Queue returns up to limit comments awaiting review, the most reported
+	// first and the newest first among equals
+	Queue(ctx context.Context, tenantID string, limit int) ([]*Item, error)
+	// Flag counts a report of a comment and queues it for review when it
+	// is ACTIVE
+	Flag(ctx context.Context, tenantID, id string) (*Item, error)
+	// Decide applies r to a comment awaiting review. It returns
+	// ErrNotQueued for a comment that is not.
+	Decide(ctx context.Context, tenantID, id string, r Review) (*Item, error)
+}
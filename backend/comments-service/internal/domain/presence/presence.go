@@ -0,0 +1,33 @@
+// Package presence models who is viewing or typing in a comment thread.
+package presence
+
+import (
+	"context"
+	"time"
+)
+
+// Thread identifies the comments of one entity within a tenant
+type Thread struct {
+	TenantID   string
+	EntityType string
+	EntityID   string
+}
+
+// Snapshot counts the sessions viewing and typing in a thread
+type Snapshot struct {
+	Viewers int
+	Typing  int
+}
+
+// Store tracks sessions per thread. Entries expire unless refreshed, so a
+// crashed instance cannot leave viewers behind.
+type Store interface {
+	// Join marks session as viewing thread for ttl
+	Join(ctx context.Context, thread Thread, session string, ttl time.Duration) error
+	// Leave removes session from thread immediately
+	Leave(ctx context.Context, thread Thread, session string) error
+	// Typing marks session as typing in thread for ttl
+	Typing(ctx context.Context, thread Thread, session string, ttl time.Duration) error
+	// Snapshots returns the current counts of each thread
+	Snapshots(ctx context.Context, threads []Thread) (map[Thread]Snapshot, error)
+}
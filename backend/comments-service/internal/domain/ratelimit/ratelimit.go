@@ -0,0 +1,74 @@
+// Package ratelimit models the per-tenant request quotas: a number of
+// requests allowed in each fixed window of a period.
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+// Windows every tenant is limited over
+const (
+	Minute = time.Minute
+	Hour   = time.Hour
+)
+
+// Counter counts requests in fixed windows
+type Counter interface {
+	// Hit counts one request against key in the window of length period
+	// containing now. It returns the count including this request and when
+	// the window ends.
+	Hit(ctx context.Context, key string, period time.Duration, now time.Time) (count int, reset time.Time, err error)
+}
+
+// Limit allows Max requests in each window of Period
+type Limit struct {
+	Period time.Duration
+	Max    int
+}
+
+// Quota is what a request left of a Limit: Remaining is negative once the
+// request went over it, and the window ends at Reset
+type Quota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// For returns the limits of t, using perMinute and perHour for those the
+// tenant has not set
+func For(t *tenant.Tenant, perMinute, perHour int) []Limit {
+	return []Limit{
+		{Period: Minute, Max: orDefault(t.RateLimitPerMinute, perMinute)},
+		{Period: Hour, Max: orDefault(t.RateLimitPerHour, perHour)},
+	}
+}
+
+// Take counts one request of key against each of limits in turn. It
+// returns the quota closest to running out, or the first one the request
+// exceeded with ok false; the limits after that one are not counted.
+func Take(ctx context.Context, counter Counter, key string, limits []Limit, now time.Time) (closest Quota, ok bool, err error) {
+	for i, l := range limits {
+		count, reset, err := counter.Hit(ctx, key, l.Period, now)
+		if err != nil {
+			return Quota{}, false, err
+		}
+		q := Quota{Limit: l.Max, Remaining: l.Max - count, Reset: reset}
+		if q.Remaining < 0 {
+			return q, false, nil
+		}
+		if i == 0 || q.Remaining < closest.Remaining {
+			closest = q
+		}
+	}
+	return closest, true, nil
+}
+
+func orDefault(limit, fallback int) int {
+	if limit > 0 {
+		return limit
+	}
+	return fallback
+}
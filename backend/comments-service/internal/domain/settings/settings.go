@@ -0,0 +1,164 @@
+// Package settings models the per-tenant feature flags and settings stored
+// in the tenants.features and tenants.settings JSONB columns. Values a
+// tenant has not set fall back to the defaults of its plan.
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/email"
+)
+
+// ModerationMode selects how new comments are reviewed
+type ModerationMode string
+
+const (
+	// ModerationAuto publishes comments unless the filters flag them
+	ModerationAuto ModerationMode = "auto"
+	// ModerationManual holds every comment for review
+	ModerationManual ModerationMode = "manual"
+	// ModerationOff publishes every comment
+	ModerationOff ModerationMode = "off"
+)
+
+// IsValid reports whether m is a known moderation mode
+func (m ModerationMode) IsValid() bool {
+	switch m {
+	case ModerationAuto, ModerationManual, ModerationOff:
+		return true
+	}
+	return false
+}
+
+// Bounds of the numeric settings, matching the comments table CHECK
+// constraints
+const (
+	MaxCommentLength = 10000
+	MaxNestingDepth  = 100
+)
+
+// Features are the boolean flags of tenants.features
+type Features struct {
+	CommentsEnabled    bool `json:"comments_enabled"`
+	LikesEnabled       bool `json:"likes_enabled"`
+	RealTimeEnabled    bool `json:"real_time_enabled"`
+	AdvancedModeration bool `json:"advanced_moderation"`
+	CustomBranding     bool `json:"custom_branding"`
+	APIAccess          bool `json:"api_access"`
+	WebhooksEnabled    bool `json:"webhooks_enabled"`
+	AnalyticsEnabled   bool `json:"analytics_enabled"`
+}
+
+// Settings is the resolved configuration of one tenant: the values of
+// tenants.settings, plus its Features
+type Settings struct {
+	ModerationMode           ModerationMode `json:"moderation_mode"`
+	SpamFilterEnabled        bool           `json:"spam_filter_enabled"`
+	ProfanityFilterEnabled   bool           `json:"profanity_filter_enabled"`
+	MaxCommentLength         int            `json:"max_comment_length"`
+	MaxNestingDepth          int            `json:"max_nesting_depth"`
+	AllowAnonymous           bool           `json:"allow_anonymous"`
+	RequireEmailVerification bool           `json:"require_email_verification"`
+	EmailAllowedDomains      []string       `json:"email_allowed_domains"`
+	EmailDeniedDomains       []string       `json:"email_denied_domains"`
+	BlockDisposableEmail     bool           `json:"block_disposable_email"`
+
+	Features Features `json:"-"`
+}
+
+// EmailPolicy returns the domains the tenant accepts email addresses from
+func (s *Settings) EmailPolicy() email.Policy {
+	return email.Policy{
+		AllowedDomains:  s.EmailAllowedDomains,
+		DeniedDomains:   s.EmailDeniedDomains,
+		BlockDisposable: s.BlockDisposableEmail,
+	}
+}
+
+// Defaults returns the settings of a tenant on plan that has set nothing
+// itself. Unknown plans get the FREE defaults.
+func Defaults(plan tenant.Plan) *Settings {
+	s := &Settings{
+		ModerationMode:           ModerationAuto,
+		SpamFilterEnabled:        true,
+		ProfanityFilterEnabled:   true,
+		MaxCommentLength:         5000,
+		MaxNestingDepth:          5,
+		RequireEmailVerification: true,
+		Features: Features{
+			CommentsEnabled: true,
+			LikesEnabled:    true,
+			APIAccess:       true,
+		},
+	}
+	switch plan {
+	case tenant.PlanEnterprise:
+		s.Features.CustomBranding = true
+		fallthrough
+	case tenant.PlanBusiness:
+		s.Features.AdvancedModeration = true
+		s.Features.WebhooksEnabled = true
+		s.Features.AnalyticsEnabled = true
+		fallthrough
+	case tenant.PlanStarter:
+		s.Features.RealTimeEnabled = true
+		s.MaxCommentLength = MaxCommentLength
+		s.MaxNestingDepth = 10
+	}
+	return s
+}
+
+// Resolve merges the stored features and settings over the defaults of
+// plan. Malformed JSON is reported, but the defaults are still returned so
+// a bad row cannot take the tenant offline.
+func Resolve(plan tenant.Plan, features, values json.RawMessage) (*Settings, error) {
+	s := Defaults(plan)
+	if len(features) > 0 {
+		if err := json.Unmarshal(features, &s.Features); err != nil {
+			return Defaults(plan), fmt.Errorf("decode tenant features: %w", err)
+		}
+	}
+	if len(values) > 0 {
+		if err := json.Unmarshal(values, s); err != nil {
+			return Defaults(plan), fmt.Errorf("decode tenant settings: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Repository stores tenant features and settings
+type Repository interface {
+	// UpdateSettings merges the given keys into the stored features and settings of
+	// tenantID. Either patch may be empty. tenant.ErrNotFound is returned for
+	// unknown and deleted tenants.
+	UpdateSettings(ctx context.Context, tenantID string, features, values json.RawMessage) error
+}
+
+// Invalidator tells every replica that a tenant's settings changed
+type Invalidator interface {
+	Invalidate(ctx context.Context, tenantID string) error
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying s
+func NewContext(ctx context.Context, s *Settings) context.Context {
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// For returns the settings of the request's tenant. They are resolved from
+// the tenant in ctx when no middleware stored them; without a tenant the
+// FREE defaults apply. The result is shared and must not be modified.
+func For(ctx context.Context) *Settings {
+	if s, ok := ctx.Value(contextKey{}).(*Settings); ok && s != nil {
+		return s
+	}
+	if t, ok := tenant.FromContext(ctx); ok {
+		s, _ := Resolve(t.Plan, t.Features, t.Settings)
+		return s
+	}
+	return Defaults(tenant.PlanFree)
+}
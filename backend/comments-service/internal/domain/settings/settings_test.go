@@ -0,0 +1,188 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+func TestResolve(t *testing.T) {
+	s, err := Resolve(tenant.PlanBusiness,
+		json.RawMessage(`{"real_time_enabled": false}`),
+		json.RawMessage(`{"max_nesting_depth": 3, "moderation_mode": "manual"}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Features.RealTimeEnabled || !s.Features.WebhooksEnabled {
+		t.Errorf("expected stored flags over plan defaults, got %+v", s.Features)
+	}
+	if s.MaxNestingDepth != 3 || s.ModerationMode != ModerationManual || s.MaxCommentLength != MaxCommentLength {
+		t.Errorf("expected stored settings over plan defaults, got %+v", s)
+	}
+
+	// A malformed row falls back to the plan defaults
+	s, err = Resolve(tenant.PlanFree, nil, json.RawMessage(`{"max_nesting_depth": "deep"}`))
+	if err == nil {
+		t.Error("expected malformed settings to be reported")
+	}
+	if s.MaxNestingDepth != Defaults(tenant.PlanFree).MaxNestingDepth {
+		t.Errorf("expected FREE default depth, got %d", s.MaxNestingDepth)
+	}
+}
+
+// Column defaults of migration 001, which 011 strips from stored rows
+const (
+	migration001Features = `{"comments_enabled": true, "likes_enabled": true, "real_time_enabled": false,
+		"advanced_moderation": false, "custom_branding": false, "api_access": true,
+		"webhooks_enabled": false, "analytics_enabled": false}`
+	migration001Settings = `{"moderation_mode": "auto", "spam_filter_enabled": true, "profanity_filter_enabled": true,
+		"max_comment_length": 10000, "max_nesting_depth": 10, "allow_anonymous": false,
+		"require_email_verification": true}`
+)
+
+// stripDefaults removes the keys of row holding the value in defaults, as
+// migration 011 does
+func stripDefaults(t *testing.T, row, defaults string) json.RawMessage {
+	t.Helper()
+	var stored, old map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(row), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(defaults), &old); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range stored {
+		if string(old[key]) == string(value) {
+			delete(stored, key)
+		}
+	}
+	out, _ := json.Marshal(stored)
+	return out
+}
+
+func TestResolve_Migration001Row(t *testing.T) {
+	// Before 011 the column defaults beat the plan defaults
+	s, _ := Resolve(tenant.PlanStarter, json.RawMessage(migration001Features), json.RawMessage(migration001Settings))
+	if s.Features.RealTimeEnabled {
+		t.Fatalf("expected the stored column default to win before the migration")
+	}
+
+	// A tenant that only overrode moderation keeps that override
+	settingsRow := strings.Replace(migration001Settings, `"auto"`, `"manual"`, 1)
+	features := stripDefaults(t, migration001Features, migration001Features)
+	values := stripDefaults(t, settingsRow, migration001Settings)
+	if string(features) != "{}" || string(values) != `{"moderation_mode":"manual"}` {
+		t.Fatalf("unexpected stripped rows %s %s", features, values)
+	}
+
+	tests := []struct {
+		plan      tenant.Plan
+		realTime  bool
+		maxLength int
+		depth     int
+	}{
+		{tenant.PlanFree, false, 5000, 5},
+		{tenant.PlanStarter, true, MaxCommentLength, 10},
+		{tenant.PlanBusiness, true, MaxCommentLength, 10},
+		{tenant.PlanEnterprise, true, MaxCommentLength, 10},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.plan), func(t *testing.T) {
+			s, err := Resolve(tt.plan, features, values)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s.Features.RealTimeEnabled != tt.realTime || s.MaxCommentLength != tt.maxLength || s.MaxNestingDepth != tt.depth {
+				t.Errorf("expected plan defaults, got %+v", s)
+			}
+			if s.ModerationMode != ModerationManual {
+				t.Errorf("expected the override to stay, got %s", s.ModerationMode)
+			}
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	if got := For(context.Background()); got.MaxNestingDepth != Defaults(tenant.PlanFree).MaxNestingDepth {
+		t.Errorf("expected FREE defaults without a tenant, got %+v", got)
+	}
+
+	tn := &tenant.Tenant{ID: "tenant-1", Plan: tenant.PlanStarter, Settings: json.RawMessage(`{"max_nesting_depth": 7}`)}
+	ctx := tenant.NewContext(context.Background(), tn)
+	if got := For(ctx); got.MaxNestingDepth != 7 {
+		t.Errorf("expected settings resolved from the tenant, got %d", got.MaxNestingDepth)
+	}
+
+	cached := &Settings{MaxNestingDepth: 2}
+	if got := For(NewContext(ctx, cached)); got != cached {
+		t.Error("expected settings stored in the context")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(tenant.PlanBusiness, json.RawMessage(`{"webhooks_enabled": true}`), json.RawMessage(`{"max_comment_length": 500, "email_denied_domains": ["spam.example", "bücher.de"]}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate(tenant.PlanFree, nil, nil); err != nil {
+		t.Errorf("expected empty update to be valid, got %v", err)
+	}
+
+	err := Validate(
+		tenant.PlanBusiness,
+		json.RawMessage(`{"webhooks_enabled": "yes", "teleport": true}`),
+		json.RawMessage(`{"max_nesting_depth": 101, "moderation_mode": "strict", "max_comment_length": 1.5, "email_allowed_domains": ["not a domain"], "email_denied_domains": "spam.example"}`),
+	)
+	var verr *ValidationError
+	if !stderrors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	want := map[string]bool{
+		"features.webhooks_enabled":      true,
+		"features.teleport":              true,
+		"settings.max_nesting_depth":     true,
+		"settings.moderation_mode":       true,
+		"settings.max_comment_length":    true,
+		"settings.email_allowed_domains": true,
+		"settings.email_denied_domains":  true,
+	}
+	if len(verr.Errors) != len(want) {
+		t.Errorf("expected %d errors, got %v", len(want), verr.Errors)
+	}
+	for _, fe := range verr.Errors {
+		if !want[fe.Field] {
+			t.Errorf("unexpected error for %s: %s", fe.Field, fe.Message)
+		}
+	}
+
+	if err := Validate(tenant.PlanFree, nil, json.RawMessage(`[1]`)); err == nil {
+		t.Error("expected non-object settings to be rejected")
+	}
+}
+
+func TestValidate_Plan(t *testing.T) {
+	if err := Validate(tenant.PlanFree, json.RawMessage(`{"likes_enabled": false, "webhooks_enabled": false}`), nil); err != nil {
+		t.Errorf("expected turning features off to be valid, got %v", err)
+	}
+	if err := Validate(tenant.PlanStarter, json.RawMessage(`{"real_time_enabled": true}`), nil); err != nil {
+		t.Errorf("expected a feature of the plan to be valid, got %v", err)
+	}
+
+	err := Validate(tenant.PlanFree, json.RawMessage(`{"webhooks_enabled": true, "custom_branding": true, "comments_enabled": true}`), nil)
+	var verr *ValidationError
+	if !stderrors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(verr.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %v", verr.Errors)
+	}
+	for _, fe := range verr.Errors {
+		if fe.Rule != "plan" || fe.Params["plan"] != "FREE" {
+			t.Errorf("expected plan violation for %s, got %s %v", fe.Field, fe.Rule, fe.Params)
+		}
+	}
+}
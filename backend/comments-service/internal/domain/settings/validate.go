@@ -0,0 +1,198 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/email"
+)
+
+// FieldError is a problem with one key of an update, named by its column
+// and key, as in settings.max_nesting_depth. Rule and Params say which check
+// failed, as in pkg/errors.FieldError.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Params  map[string]any
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every invalid key of an update
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid tenant settings: " + strings.Join(msgs, "; ")
+}
+
+// rule checks one raw JSON value, returning the failed check when it is
+// invalid
+type rule func(raw json.RawMessage) *FieldError
+
+// featureRules is the schema of tenants.features for a tenant on plan. A
+// tenant may turn off any feature, but only turn on those its plan includes;
+// keys outside the schema are rejected.
+func featureRules(plan tenant.Plan) map[string]rule {
+	included := Defaults(plan).Features
+	return map[string]rule{
+		"comments_enabled":    isFeature(plan, included.CommentsEnabled),
+		"likes_enabled":       isFeature(plan, included.LikesEnabled),
+		"real_time_enabled":   isFeature(plan, included.RealTimeEnabled),
+		"advanced_moderation": isFeature(plan, included.AdvancedModeration),
+		"custom_branding":     isFeature(plan, included.CustomBranding),
+		"api_access":          isFeature(plan, included.APIAccess),
+		"webhooks_enabled":    isFeature(plan, included.WebhooksEnabled),
+		"analytics_enabled":   isFeature(plan, included.AnalyticsEnabled),
+	}
+}
+
+// settingRules is the schema of tenants.settings; keys outside it are
+// rejected
+var settingRules = map[string]rule{
+	"moderation_mode":            isOneOf(string(ModerationAuto), string(ModerationManual), string(ModerationOff)),
+	"spam_filter_enabled":        isBool,
+	"profanity_filter_enabled":   isBool,
+	"max_comment_length":         isIntBetween(1, MaxCommentLength),
+	"max_nesting_depth":          isIntBetween(0, MaxNestingDepth),
+	"allow_anonymous":            isBool,
+	"require_email_verification": isBool,
+	"email_allowed_domains":      isDomainList,
+	"email_denied_domains":       isDomainList,
+	"block_disposable_email":     isBool,
+}
+
+// Validate checks partial updates of tenants.features and tenants.settings
+// of a tenant on plan against the schema. Either may be empty. The error is
+// a *ValidationError listing every invalid key.
+func Validate(plan tenant.Plan, features, values json.RawMessage) error {
+	var errs []FieldError
+	errs = append(errs, validateObject("features", features, featureRules(plan))...)
+	errs = append(errs, validateObject("settings", values, settingRules)...)
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func validateObject(column string, raw json.RawMessage, rules map[string]rule) []FieldError {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+		return []FieldError{{Field: column, Rule: "type", Params: map[string]any{"type": "object"}, Message: "must be a JSON object"}}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []FieldError
+	for _, key := range keys {
+		check, ok := rules[key]
+		if !ok {
+			errs = append(errs, FieldError{Field: column + "." + key, Rule: "known_key", Message: "is not a known key"})
+			continue
+		}
+		if fe := check(obj[key]); fe != nil {
+			fe.Field = column + "." + key
+			errs = append(errs, *fe)
+		}
+	}
+	return errs
+}
+
+func isBool(raw json.RawMessage) *FieldError {
+	var b bool
+	if json.Unmarshal(raw, &b) != nil {
+		return &FieldError{Rule: "type", Params: map[string]any{"type": "boolean"}, Message: "must be a boolean"}
+	}
+	return nil
+}
+
+// isFeature accepts a boolean, but true only when the feature is included
+// in plan
+func isFeature(plan tenant.Plan, included bool) rule {
+	return func(raw json.RawMessage) *FieldError {
+		if fe := isBool(raw); fe != nil {
+			return fe
+		}
+		var enabled bool
+		_ = json.Unmarshal(raw, &enabled)
+		if enabled && !included {
+			return &FieldError{
+				Rule:    "plan",
+				Params:  map[string]any{"plan": string(plan)},
+				Message: fmt.Sprintf("is not included in the %s plan", plan),
+			}
+		}
+		return nil
+	}
+}
+
+// maxDomainListLength caps the email domain lists, which are checked on
+// every comment with an author email
+const maxDomainListLength = 1000
+
+func isDomainList(raw json.RawMessage) *FieldError {
+	var domains []string
+	if json.Unmarshal(raw, &domains) != nil {
+		return &FieldError{Rule: "type", Params: map[string]any{"type": "list of strings"}, Message: "must be a list of strings"}
+	}
+	if len(domains) > maxDomainListLength {
+		return &FieldError{Rule: "max", Params: map[string]any{"max": maxDomainListLength}, Message: fmt.Sprintf("must be at most %d", maxDomainListLength)}
+	}
+	for _, d := range domains {
+		if _, err := email.NormalizeDomain(d); err != nil {
+			return &FieldError{Rule: "domain", Params: map[string]any{"value": d}, Message: fmt.Sprintf("%q is not a domain name", d)}
+		}
+	}
+	return nil
+}
+
+func isIntBetween(min, max int) rule {
+	return func(raw json.RawMessage) *FieldError {
+		var n int
+		if json.Unmarshal(raw, &n) != nil {
+			return &FieldError{Rule: "type", Params: map[string]any{"type": "integer"}, Message: "must be an integer"}
+		}
+		if n < min || n > max {
+			return &FieldError{
+				Rule:    "range",
+				Params:  map[string]any{"min": min, "max": max},
+				Message: fmt.Sprintf("must be between %d and %d", min, max),
+			}
+		}
+		return nil
+	}
+}
+
+func isOneOf(values ...string) rule {
+	return func(raw json.RawMessage) *FieldError {
+		var s string
+		if json.Unmarshal(raw, &s) != nil || !slices.Contains(values, s) {
+			return &FieldError{
+				Rule:    "oneof",
+				Params:  map[string]any{"values": values},
+				Message: "must be one of " + strings.Join(values, ", "),
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+// Package tenant models the tenant that owns every request and the
+// context helpers used to carry it through the layers.
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// Plan mirrors the tenant_plan enum
+type Plan string
+
+const (
+	PlanFree       Plan = "FREE"
+	PlanStarter    Plan = "STARTER"
+	PlanBusiness   Plan = "BUSINESS"
+	PlanEnterprise Plan = "ENTERPRISE"
+)
+
+// Status mirrors the tenant_status enum
+type Status string
+
+const (
+	StatusActive    Status = "ACTIVE"
+	StatusSuspended Status = "SUSPENDED"
+	StatusInactive  Status = "INACTIVE"
+	StatusDeleted   Status = "DELETED"
+)
+
+// Tenant is the authenticated tenant resolved from an API key
+type Tenant struct {
+	ID                 string          `json:"id"`
+	Name               string          `json:"name"`
+	Subdomain          string          `json:"subdomain"`
+	Plan               Plan            `json:"plan"`
+	Status             Status          `json:"status"`
+	RateLimitPerMinute int             `json:"rate_limit_per_minute"`
+	RateLimitPerHour   int             `json:"rate_limit_per_hour"`
+	Features           json.RawMessage `json:"features"`
+	Settings           json.RawMessage `json:"settings"`
+	Scopes             []string        `json:"scopes"`
+}
+
+// IsActive reports whether the tenant may serve requests
+func (t *Tenant) IsActive() bool {
+	return t.Status == StatusActive
+}
+
+// ScopeAdmin lets an API key change the tenant's own settings
+const ScopeAdmin = "admin"
+
+// HasScope reports whether the API key used for the request grants scope
+func (t *Tenant) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying t
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant stored in ctx, if any
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(contextKey{}).(*Tenant)
+	return t, ok && t != nil
+}
+
+// ErrNotFound is returned when no tenant matches a lookup
+var ErrNotFound = errors.New("tenant not found")
+
+// Repository loads tenants from storage
+type Repository interface {
+	// FindByAPIKeyHash resolves the tenant owning a valid, unrevoked API key
+	// stored under one of hashes, the key hashed under each pepper in use,
+	// and returns the hash that matched
+	FindByAPIKeyHash(ctx context.Context, hashes []string) (*Tenant, string, error)
+	// RehashAPIKey replaces the stored hash of a key with one made under
+	// pepperVersion
+	RehashAPIKey(ctx context.Context, oldHash, newHash string, pepperVersion int) error
+}
+
+// SubdomainRepository reports which subdomains are registered
+type SubdomainRepository interface {
+	// TakenSubdomains returns the candidates some tenant, deleted ones
+	// included, already holds
+	TakenSubdomains(ctx context.Context, candidates []string) ([]string, error)
+}
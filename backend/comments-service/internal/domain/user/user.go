@@ -0,0 +1,39 @@
+// Package user models the end users of a tenant, as far as this service
+// needs them.
+package user
+
+import (
+	"context"
+	"errors"
+)
+
+// Preferences mirrors the users.preferences column
+type Preferences struct {
+	EmailNotifications bool `json:"email_notifications"`
+	PushNotifications  bool `json:"push_notifications"`
+	Newsletter         bool `json:"newsletter"`
+	// Language is a BCP 47 tag such as "en" or "pt-BR"
+	Language string `json:"language"`
+	// Timezone is an IANA name such as "Europe/Berlin"
+	Timezone string `json:"timezone"`
+	Theme    string `json:"theme"`
+}
+
+// DefaultPreferences are the column default of users.preferences
+func DefaultPreferences() Preferences {
+	return Preferences{
+		EmailNotifications: true,
+		Language:           "en",
+		Timezone:           "UTC",
+		Theme:              "light",
+	}
+}
+
+// ErrNotFound is returned when no user matches a lookup
+var ErrNotFound = errors.New("user not found")
+
+// Repository loads users from storage
+type Repository interface {
+	// Preferences returns the preferences of a live user of the tenant
+	Preferences(ctx context.Context, tenantID, userID string) (*Preferences, error)
+}
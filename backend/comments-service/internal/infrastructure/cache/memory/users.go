@@ -0,0 +1,115 @@
+// Package memory holds in-process caches for lookups made on every request,
+// where a Redis round trip would cost about as much as the query it saves.
+package memory
+
+import (
+	"container/list"
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+)
+
+// Users caches user preferences in front of a user.Repository. It holds at
+// most size users, evicting the least recently used, each for ttl, so a
+// changed preference applies within ttl. Unknown users are cached as well:
+// a client repeating an unknown X-User-ID does not reach the database.
+type Users struct {
+	repo user.Repository
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[userKey]*list.Element
+	// recent orders the entries, most recently used first
+	recent *list.List
+}
+
+type userKey struct {
+	tenantID string
+	userID   string
+}
+
+type userEntry struct {
+	key     userKey
+	prefs   *user.Preferences
+	expires time.Time
+}
+
+// NewUsers caches the preferences of up to size users of repo for ttl
+func NewUsers(repo user.Repository, size int, ttl time.Duration) *Users {
+	return &Users{
+		repo:    repo,
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[userKey]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+var _ user.Repository = (*Users)(nil)
+
+// Preferences returns the cached preferences, loading them from the
+// repository when missing or expired. Errors other than user.ErrNotFound
+// are not cached.
+func (u *Users) Preferences(ctx context.Context, tenantID, userID string) (*user.Preferences, error) {
+	key := userKey{tenantID: tenantID, userID: userID}
+	if prefs, ok := u.get(key); ok {
+		return found(prefs)
+	}
+
+	prefs, err := u.repo.Preferences(ctx, tenantID, userID)
+	if err != nil && !stderrors.Is(err, user.ErrNotFound) {
+		return nil, err
+	}
+	u.put(key, prefs)
+	return found(prefs)
+}
+
+// found returns a copy of prefs, so callers cannot change the cached value;
+// nil stands for an unknown user
+func found(prefs *user.Preferences) (*user.Preferences, error) {
+	if prefs == nil {
+		return nil, user.ErrNotFound
+	}
+	p := *prefs
+	return &p, nil
+}
+
+func (u *Users) get(key userKey) (*user.Preferences, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	el, ok := u.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*userEntry)
+	if !u.now().Before(e.expires) {
+		u.recent.Remove(el)
+		delete(u.entries, key)
+		return nil, false
+	}
+	u.recent.MoveToFront(el)
+	return e.prefs, true
+}
+
+func (u *Users) put(key userKey, prefs *user.Preferences) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	e := &userEntry{key: key, prefs: prefs, expires: u.now().Add(u.ttl)}
+	if el, ok := u.entries[key]; ok {
+		el.Value = e
+		u.recent.MoveToFront(el)
+		return
+	}
+	u.entries[key] = u.recent.PushFront(e)
+	for u.recent.Len() > u.size {
+		oldest := u.recent.Back()
+		u.recent.Remove(oldest)
+		delete(u.entries, oldest.Value.(*userEntry).key)
+	}
+}
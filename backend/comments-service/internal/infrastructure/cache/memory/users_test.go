@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+)
+
+// fakeUsers knows the users listed in languages and counts its lookups
+type fakeUsers struct {
+	languages map[string]string
+	lookups   int
+	err       error
+}
+
+func (f *fakeUsers) Preferences(ctx context.Context, tenantID, userID string) (*user.Preferences, error) {
+	f.lookups++
+	if f.err != nil {
+		return nil, f.err
+	}
+	lang, ok := f.languages[userID]
+	if !ok {
+		return nil, user.ErrNotFound
+	}
+	prefs := user.DefaultPreferences()
+	prefs.Language = lang
+	return &prefs, nil
+}
+
+func TestUsers(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeUsers{languages: map[string]string{"u1": "de", "u2": "fr", "u3": "es"}}
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	cache := NewUsers(repo, 2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	lookup := func(userID string) (string, error) {
+		prefs, err := cache.Preferences(ctx, "tenant-1", userID)
+		if err != nil {
+			return "", err
+		}
+		return prefs.Language, nil
+	}
+
+	if lang, _ := lookup("u1"); lang != "de" {
+		t.Errorf("expected de, got %q", lang)
+	}
+	prefs, _ := cache.Preferences(ctx, "tenant-1", "u1")
+	prefs.Language = "changed"
+	if lang, _ := lookup("u1"); lang != "de" || repo.lookups != 1 {
+		t.Errorf("expected a cached, unchanged de after 1 lookup, got %q after %d", lang, repo.lookups)
+	}
+
+	// Unknown users are cached too
+	for range 2 {
+		if _, err := lookup("ghost"); !stderrors.Is(err, user.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if repo.lookups != 2 {
+		t.Errorf("expected the unknown user to be looked up once, got %d lookups", repo.lookups)
+	}
+
+	// u1 was used before ghost, so u2 evicts it
+	lookup("u2")
+	lookup("u1")
+	if repo.lookups != 4 {
+		t.Errorf("expected u1 to be evicted, got %d lookups", repo.lookups)
+	}
+	if len(cache.entries) != 2 || cache.recent.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", len(cache.entries))
+	}
+
+	// Entries expire
+	now = now.Add(time.Minute)
+	lookup("u1")
+	if repo.lookups != 5 {
+		t.Errorf("expected an expired entry to be reloaded, got %d lookups", repo.lookups)
+	}
+
+	// Failures are not cached
+	repo.err = stderrors.New("database down")
+	for range 2 {
+		if _, err := lookup("u3"); err == nil {
+			t.Error("expected the failure to be returned")
+		}
+	}
+	if repo.lookups != 7 {
+		t.Errorf("expected failures to be retried, got %d lookups", repo.lookups)
+	}
+}
@@ -0,0 +1,42 @@
+// Package redis builds the Redis client shared by the cache, pub/sub and
+// coordination features.
+package redis
+
+import (
+	"context"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+)
+
+// NewClient creates a client for the configured Redis server, or cluster
+// when REDIS_CLUSTER_ENABLED is set. Connections are opened lazily on first
+// use, each asking password for the current password when it is set.
+func NewClient(cfg *config.Config, password func() string) goredis.UniversalClient {
+	credentials := func() (string, string) {
+		return "", password()
+	}
+	if password == nil {
+		credentials = nil
+	}
+
+	if cfg.Redis.ClusterEnabled {
+		return goredis.NewClusterClient(&goredis.ClusterOptions{
+			Addrs:               cfg.Redis.ClusterAddrs,
+			Password:            cfg.Redis.Password,
+			CredentialsProvider: credentials,
+		})
+	}
+	return goredis.NewClient(&goredis.Options{
+		Addr:                cfg.RedisAddr(),
+		Password:            cfg.Redis.Password,
+		DB:                  cfg.Redis.DB,
+		CredentialsProvider: credentials,
+	})
+}
+
+// Ping reports whether the server answers
+func Ping(ctx context.Context, client goredis.UniversalClient) error {
+	return client.Ping(ctx).Err()
+}
@@ -0,0 +1,278 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+)
+
+// eventsPrefix namespaces both the replay streams and the pub/sub channels
+const eventsPrefix = "comments:events:"
+
+// subscriberBuffer is how many events a subscriber may lag behind before it
+// is dropped and has to resume with Last-Event-ID
+const subscriberBuffer = 64
+
+// CommentEvents publishes comment events to a capped Redis stream per
+// entity, for Last-Event-ID replay, and to a pub/sub channel that every
+// replica fans out to its local subscribers.
+type CommentEvents struct {
+	client      goredis.UniversalClient
+	replayLimit int64
+	replayTTL   time.Duration
+
+	mu   sync.Mutex
+	subs map[string]map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	live chan comment.Event
+	once sync.Once
+}
+
+func (s *subscriber) close() {
+	s.once.Do(func() { close(s.live) })
+}
+
+// NewCommentEvents keeps up to replayLimit events per entity for replayTTL
+// after the last one was published
+func NewCommentEvents(client goredis.UniversalClient, replayLimit int64, replayTTL time.Duration) *CommentEvents {
+	return &CommentEvents{
+		client:      client,
+		replayLimit: replayLimit,
+		replayTTL:   replayTTL,
+		subs:        make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// eventsKey names the stream and channel of an entity. The parts are escaped
+// so ids containing ':' cannot collide.
+func eventsKey(tenantID, entityType, entityID string) string {
+	return eventsPrefix + url.QueryEscape(tenantID) + ":" + url.QueryEscape(entityType) + ":" + url.QueryEscape(entityID)
+}
+
+// Publish appends e to the entity's stream, which assigns its ID, and then
+// broadcasts it to every replica
+func (p *CommentEvents) Publish(ctx context.Context, e comment.Event) error {
+	key := eventsKey(e.TenantID, e.EntityType, e.EntityID)
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	id, err := p.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: key,
+		MaxLen: p.replayLimit,
+		Approx: true,
+		Values: map[string]any{"event": payload},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("append event: %w", err)
+	}
+
+	e.ID = id
+	payload, err = json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	pipe := p.client.Pipeline()
+	pipe.Expire(ctx, key, p.replayTTL)
+	pipe.Publish(ctx, key, payload)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("broadcast event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe streams the events of one entity, replaying those after
+// lastEventID first. An unknown or malformed lastEventID starts with live
+// events only.
+func (p *CommentEvents) Subscribe(ctx context.Context, tenantID, entityType, entityID, lastEventID string) (<-chan comment.Event, error) {
+	key := eventsKey(tenantID, entityType, entityID)
+	sub := &subscriber{live: make(chan comment.Event, subscriberBuffer)}
+
+	// Register before reading the backlog so nothing published in between
+	// is lost; duplicates are skipped by ID below
+	p.mu.Lock()
+	if p.subs[key] == nil {
+		p.subs[key] = make(map[*subscriber]struct{})
+	}
+	p.subs[key][sub] = struct{}{}
+	p.mu.Unlock()
+
+	var backlog []comment.Event
+	if validStreamID(lastEventID) {
+		msgs, err := p.client.XRangeN(ctx, key, "("+lastEventID, "+", p.replayLimit).Result()
+		if err != nil {
+			p.unsubscribe(key, sub)
+			return nil, fmt.Errorf("replay events: %w", err)
+		}
+		for _, msg := range msgs {
+			e, ok := decodeEvent(msg.Values["event"])
+			if !ok {
+				continue
+			}
+			e.ID = msg.ID
+			backlog = append(backlog, e)
+		}
+	}
+
+	out := make(chan comment.Event)
+	go func() {
+		defer close(out)
+		defer p.unsubscribe(key, sub)
+
+		last := lastEventID
+		for _, e := range backlog {
+			select {
+			case out <- e:
+				last = e.ID
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case e, ok := <-sub.live:
+				if !ok {
+					return
+				}
+				if validStreamID(last) && !streamIDAfter(e.ID, last) {
+					continue
+				}
+				select {
+				case out <- e:
+					last = e.ID
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (p *CommentEvents) unsubscribe(key string, sub *subscriber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.subs[key], sub)
+	if len(p.subs[key]) == 0 {
+		delete(p.subs, key)
+	}
+	sub.close()
+}
+
+// Run receives the broadcasts of every replica and hands them to the local
+// subscribers of the entity until ctx ends, then closes every subscription
+func (p *CommentEvents) Run(ctx context.Context) error {
+	ps := p.client.PSubscribe(ctx, eventsPrefix+"*")
+	defer ps.Close()
+	defer p.closeAll()
+
+	ch := ps.Channel()
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			e, ok := decodeEvent(msg.Payload)
+			if !ok {
+				continue
+			}
+			p.dispatch(msg.Channel, e)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// dispatch delivers e without blocking. A subscriber whose buffer is full is
+// dropped so one slow client cannot stall the others; it reconnects and
+// resumes from its last event.
+func (p *CommentEvents) dispatch(key string, e comment.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for sub := range p.subs[key] {
+		select {
+		case sub.live <- e:
+		default:
+			delete(p.subs[key], sub)
+			sub.close()
+		}
+	}
+}
+
+func (p *CommentEvents) closeAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, subs := range p.subs {
+		for sub := range subs {
+			sub.close()
+		}
+		delete(p.subs, key)
+	}
+}
+
+func decodeEvent(v any) (comment.Event, bool) {
+	var raw []byte
+	switch v := v.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return comment.Event{}, false
+	}
+
+	var e comment.Event
+	if err := json.Unmarshal(raw, &e); err != nil {
+		log.Printf("dropping malformed comment event: %v", err)
+		return comment.Event{}, false
+	}
+	return e, true
+}
+
+// parseStreamID splits a Redis stream ID of the form <ms>-<seq>
+func parseStreamID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+func validStreamID(id string) bool {
+	_, _, ok := parseStreamID(id)
+	return ok
+}
+
+// streamIDAfter reports whether stream ID a was assigned after b
+func streamIDAfter(a, b string) bool {
+	ams, aseq, _ := parseStreamID(a)
+	bms, bseq, _ := parseStreamID(b)
+	if ams != bms {
+		return ams > bms
+	}
+	return aseq > bseq
+}
@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+)
+
+func newTestEvents(t *testing.T) (*CommentEvents, context.Context) {
+	t.Helper()
+
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	events := NewCommentEvents(client, 100, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go events.Run(ctx)
+
+	// Wait for the pattern subscription before publishing
+	deadline := time.Now().Add(time.Second)
+	for srv.PubSubNumPat() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("fan-out did not subscribe")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return events, ctx
+}
+
+func testEvent(id string) comment.Event {
+	return comment.Event{
+		Type:       comment.EventCreated,
+		TenantID:   "tenant-1",
+		EntityType: "post",
+		EntityID:   "post_1",
+		CommentID:  id,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+func receive(t *testing.T, ch <-chan comment.Event) comment.Event {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return comment.Event{}
+}
+
+func TestCommentEvents_Live(t *testing.T) {
+	events, ctx := newTestEvents(t)
+
+	ch, err := events.Subscribe(ctx, "tenant-1", "post", "post_1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other, _ := events.Subscribe(ctx, "tenant-1", "post", "post_2", "")
+
+	if err := events.Publish(ctx, testEvent("c1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e := receive(t, ch)
+	if e.CommentID != "c1" || e.ID == "" {
+		t.Errorf("expected c1 with a stream id, got %+v", e)
+	}
+	select {
+	case e := <-other:
+		t.Errorf("expected no event for another entity, got %+v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCommentEvents_Resume(t *testing.T) {
+	events, ctx := newTestEvents(t)
+
+	ch, _ := events.Subscribe(ctx, "tenant-1", "post", "post_1", "")
+	events.Publish(ctx, testEvent("c1"))
+	first := receive(t, ch)
+	events.Publish(ctx, testEvent("c2"))
+	events.Publish(ctx, testEvent("c3"))
+
+	resumed, err := events.Subscribe(ctx, "tenant-1", "post", "post_1", first.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"c2", "c3"} {
+		if e := receive(t, resumed); e.CommentID != want {
+			t.Errorf("expected %s, got %s", want, e.CommentID)
+		}
+	}
+
+	events.Publish(ctx, testEvent("c4"))
+	if e := receive(t, resumed); e.CommentID != "c4" {
+		t.Errorf("expected c4 after the backlog, got %s", e.CommentID)
+	}
+}
+
+func TestCommentEvents_ClosedOnShutdown(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	events := NewCommentEvents(client, 100, time.Hour)
+
+	ch, _ := events.Subscribe(context.Background(), "tenant-1", "post", "post_1", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	events.Run(ctx)
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected the stream to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed")
+	}
+}
+
+func TestStreamIDAfter(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"2-0", "1-5", true},
+		{"1-6", "1-5", true},
+		{"1-5", "1-5", false},
+		{"1-4", "1-5", false},
+		{"10-0", "9-9", true},
+	}
+
+	for _, tt := range tests {
+		if got := streamIDAfter(tt.a, tt.b); got != tt.want {
+			t.Errorf("streamIDAfter(%s, %s): expected %v, got %v", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
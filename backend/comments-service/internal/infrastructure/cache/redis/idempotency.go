@@ -0,0 +1,87 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/idempotency"
+)
+
+const idempotencyPrefix = "comments:idempotency:"
+
+// IdempotencyStore keeps idempotency records as JSON strings that expire
+// with their TTL
+type IdempotencyStore struct {
+	client goredis.UniversalClient
+}
+
+// NewIdempotencyStore creates a store on client
+func NewIdempotencyStore(client goredis.UniversalClient) *IdempotencyStore {
+	return &IdempotencyStore{client: client}
+}
+
+func idempotencyKey(tenantID, key string) string {
+	return idempotencyPrefix + url.QueryEscape(tenantID) + ":" + url.QueryEscape(key)
+}
+
+// Reserve claims key with a pending record. If the key is taken, the record
+// holding it is returned instead.
+func (s *IdempotencyStore) Reserve(ctx context.Context, tenantID, key, fingerprint string, lockTTL time.Duration) (*idempotency.Record, error) {
+	pending, err := json.Marshal(idempotency.Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, fmt.Errorf("marshal idempotency record: %w", err)
+	}
+
+	redisKey := idempotencyKey(tenantID, key)
+	// The loser of a race with an expiring claim tries again rather than
+	// reporting a key that no longer exists
+	for attempt := 0; attempt < 2; attempt++ {
+		claimed, err := s.client.SetNX(ctx, redisKey, pending, lockTTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("reserve idempotency key: %w", err)
+		}
+		if claimed {
+			return nil, nil
+		}
+
+		raw, err := s.client.Get(ctx, redisKey).Bytes()
+		if err == goredis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("load idempotency key: %w", err)
+		}
+		var record idempotency.Record
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, fmt.Errorf("decode idempotency record: %w", err)
+		}
+		return &record, nil
+	}
+	return nil, fmt.Errorf("reserve idempotency key: claim kept expiring")
+}
+
+// Complete replaces the claim with the finished response
+func (s *IdempotencyStore) Complete(ctx context.Context, tenantID, key string, record idempotency.Record, ttl time.Duration) error {
+	record.Completed = true
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal idempotency record: %w", err)
+	}
+	if err := s.client.Set(ctx, idempotencyKey(tenantID, key), raw, ttl).Err(); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release drops the claim on key
+func (s *IdempotencyStore) Release(ctx context.Context, tenantID, key string) error {
+	if err := s.client.Del(ctx, idempotencyKey(tenantID, key)).Err(); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
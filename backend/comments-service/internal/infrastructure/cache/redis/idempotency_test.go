@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/idempotency"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	store := NewIdempotencyStore(client)
+	ctx := context.Background()
+
+	if got, err := store.Reserve(ctx, "tenant-1", "k1", "fp", time.Minute); err != nil || got != nil {
+		t.Fatalf("expected first reserve to claim the key, got %+v, %v", got, err)
+	}
+	got, err := store.Reserve(ctx, "tenant-1", "k1", "fp", time.Minute)
+	if err != nil || got == nil || got.Completed || got.Fingerprint != "fp" {
+		t.Fatalf("expected pending record, got %+v, %v", got, err)
+	}
+	if got, _ := store.Reserve(ctx, "tenant-2", "k1", "fp", time.Minute); got != nil {
+		t.Errorf("expected keys to be scoped per tenant, got %+v", got)
+	}
+
+	record := idempotency.Record{
+		Fingerprint: "fp",
+		StatusCode:  http.StatusCreated,
+		Header:      http.Header{"Location": {"/comments/1"}},
+		Body:        []byte(`{"data":{}}`),
+	}
+	if err := store.Complete(ctx, "tenant-1", "k1", record, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err = store.Reserve(ctx, "tenant-1", "k1", "fp", time.Minute)
+	if err != nil || got == nil || !got.Completed || got.StatusCode != http.StatusCreated || string(got.Body) != `{"data":{}}` {
+		t.Fatalf("expected completed record, got %+v, %v", got, err)
+	}
+
+	// Claims expire with the lock TTL and can be released early
+	srv.FastForward(2 * time.Minute)
+	if got, _ := store.Reserve(ctx, "tenant-2", "k1", "fp", time.Minute); got != nil {
+		t.Errorf("expected expired claim to be free, got %+v", got)
+	}
+	store.Release(ctx, "tenant-2", "k1")
+	if got, _ := store.Reserve(ctx, "tenant-2", "k1", "fp", time.Minute); got != nil {
+		t.Errorf("expected released claim to be free, got %+v", got)
+	}
+	srv.FastForward(2 * time.Hour)
+	if got, _ := store.Reserve(ctx, "tenant-1", "k1", "fp", time.Minute); got != nil {
+		t.Errorf("expected completed record to expire, got %+v", got)
+	}
+}
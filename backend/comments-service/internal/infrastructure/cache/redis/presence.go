@@ -0,0 +1,93 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/presence"
+)
+
+const presencePrefix = "comments:presence:"
+
+// Presence keeps one sorted set of viewers and one of typists per thread.
+// Members are session ids scored by their expiry time in milliseconds, so
+// expired sessions are pruned on read and shared by every instance.
+type Presence struct {
+	client goredis.UniversalClient
+}
+
+// NewPresence creates a presence store on client
+func NewPresence(client goredis.UniversalClient) *Presence {
+	return &Presence{client: client}
+}
+
+func presenceKeys(t presence.Thread) (viewers, typing string) {
+	base := presencePrefix + url.QueryEscape(t.TenantID) + ":" + url.QueryEscape(t.EntityType) + ":" + url.QueryEscape(t.EntityID)
+	return base + ":viewers", base + ":typing"
+}
+
+// Join marks session as viewing thread for ttl
+func (p *Presence) Join(ctx context.Context, thread presence.Thread, session string, ttl time.Duration) error {
+	viewers, _ := presenceKeys(thread)
+	return p.mark(ctx, viewers, session, ttl)
+}
+
+// Typing marks session as typing in thread for ttl
+func (p *Presence) Typing(ctx context.Context, thread presence.Thread, session string, ttl time.Duration) error {
+	_, typing := presenceKeys(thread)
+	return p.mark(ctx, typing, session, ttl)
+}
+
+func (p *Presence) mark(ctx context.Context, key, session string, ttl time.Duration) error {
+	expires := time.Now().Add(ttl)
+	pipe := p.client.TxPipeline()
+	pipe.ZAdd(ctx, key, goredis.Z{Score: float64(expires.UnixMilli()), Member: session})
+	// The key outlives its newest member by a little so idle threads vanish
+	pipe.PExpire(ctx, key, 2*ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("mark presence: %w", err)
+	}
+	return nil
+}
+
+// Leave removes session from thread immediately
+func (p *Presence) Leave(ctx context.Context, thread presence.Thread, session string) error {
+	viewers, typing := presenceKeys(thread)
+	pipe := p.client.TxPipeline()
+	pipe.ZRem(ctx, viewers, session)
+	pipe.ZRem(ctx, typing, session)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("leave presence: %w", err)
+	}
+	return nil
+}
+
+// Snapshots prunes expired sessions and counts the rest for every thread in
+// one round trip
+func (p *Presence) Snapshots(ctx context.Context, threads []presence.Thread) (map[presence.Thread]presence.Snapshot, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	type counts struct{ viewers, typing *goredis.IntCmd }
+	cmds := make(map[presence.Thread]counts, len(threads))
+	pipe := p.client.Pipeline()
+	for _, t := range threads {
+		viewers, typing := presenceKeys(t)
+		pipe.ZRemRangeByScore(ctx, viewers, "-inf", "("+now)
+		pipe.ZRemRangeByScore(ctx, typing, "-inf", "("+now)
+		cmds[t] = counts{viewers: pipe.ZCard(ctx, viewers), typing: pipe.ZCard(ctx, typing)}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != goredis.Nil {
+		return nil, fmt.Errorf("read presence: %w", err)
+	}
+
+	out := make(map[presence.Thread]presence.Snapshot, len(threads))
+	for t, c := range cmds {
+		out[t] = presence.Snapshot{Viewers: int(c.viewers.Val()), Typing: int(c.typing.Val())}
+	}
+	return out, nil
+}
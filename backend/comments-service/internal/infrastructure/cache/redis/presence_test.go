@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/presence"
+)
+
+func TestPresence(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	store := NewPresence(client)
+	ctx := context.Background()
+
+	thread := presence.Thread{TenantID: "tenant-1", EntityType: "post", EntityID: "post_1"}
+	other := presence.Thread{TenantID: "tenant-2", EntityType: "post", EntityID: "post_1"}
+
+	store.Join(ctx, thread, "s1", time.Minute)
+	store.Join(ctx, thread, "s2", time.Minute)
+	store.Join(ctx, other, "s3", time.Minute)
+	store.Typing(ctx, thread, "s1", 20*time.Millisecond)
+
+	got, err := store.Snapshots(ctx, []presence.Thread{thread, other})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[thread] != (presence.Snapshot{Viewers: 2, Typing: 1}) {
+		t.Errorf("expected 2 viewers and 1 typing, got %+v", got[thread])
+	}
+	if got[other] != (presence.Snapshot{Viewers: 1}) {
+		t.Errorf("expected tenants to be isolated, got %+v", got[other])
+	}
+
+	// Typing expires on its own; leaving is immediate
+	time.Sleep(30 * time.Millisecond)
+	store.Leave(ctx, thread, "s2")
+
+	got, _ = store.Snapshots(ctx, []presence.Thread{thread})
+	if got[thread] != (presence.Snapshot{Viewers: 1}) {
+		t.Errorf("expected 1 viewer and nobody typing, got %+v", got[thread])
+	}
+}
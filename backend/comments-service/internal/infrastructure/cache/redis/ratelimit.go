@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/ratelimit"
+)
+
+const rateLimitPrefix = "comments:ratelimit:"
+
+// RateLimitCounter keeps one counter per key and window, expiring with the
+// window
+type RateLimitCounter struct {
+	client goredis.UniversalClient
+}
+
+// NewRateLimitCounter creates a counter on client
+func NewRateLimitCounter(client goredis.UniversalClient) *RateLimitCounter {
+	return &RateLimitCounter{client: client}
+}
+
+var _ ratelimit.Counter = (*RateLimitCounter)(nil)
+
+// Hit increments the counter of the window containing now
+func (r *RateLimitCounter) Hit(ctx context.Context, key string, period time.Duration, now time.Time) (int, time.Time, error) {
+	start := now.Truncate(period)
+	reset := start.Add(period)
+	redisKey := rateLimitPrefix + url.QueryEscape(key) + ":" +
+		strconv.FormatInt(int64(period/time.Second), 10) + ":" + strconv.FormatInt(start.Unix(), 10)
+
+	var incr *goredis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, redisKey)
+		// Relative, so the window expires on time whatever the Redis clock says
+		pipe.Expire(ctx, redisKey, reset.Sub(now)+time.Second)
+		return nil
+	})
+	if err != nil {
+		return 0, reset, fmt.Errorf("count request: %w", err)
+	}
+	return int(incr.Val()), reset, nil
+}
@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestRateLimitCounter(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	counter := NewRateLimitCounter(client)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 30, 15, 0, time.UTC)
+
+	for want := 1; want <= 3; want++ {
+		count, reset, err := counter.Hit(ctx, "tenant-1", time.Minute, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != want || !reset.Equal(time.Date(2025, 3, 10, 12, 31, 0, 0, time.UTC)) {
+			t.Errorf("expected count %d resetting at 12:31, got %d at %s", want, count, reset)
+		}
+	}
+
+	if count, _, _ := counter.Hit(ctx, "tenant-2", time.Minute, now); count != 1 {
+		t.Errorf("expected counters per key, got %d", count)
+	}
+	if count, _, _ := counter.Hit(ctx, "tenant-1", time.Hour, now); count != 1 {
+		t.Errorf("expected counters per period, got %d", count)
+	}
+	if count, _, _ := counter.Hit(ctx, "tenant-1", time.Minute, now.Add(time.Minute)); count != 1 {
+		t.Errorf("expected a new window to start over, got %d", count)
+	}
+
+	srv.FastForward(2 * time.Minute)
+	if srv.Exists("comments:ratelimit:tenant-1:60:" + "1741609800") {
+		t.Errorf("expected the window to expire")
+	}
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+)
+
+// settingsChannel carries the ids of tenants whose settings changed
+const settingsChannel = "comments:tenant-settings"
+
+// SettingsInvalidations broadcasts tenant settings changes so every replica
+// drops its cached copy
+type SettingsInvalidations struct {
+	client goredis.UniversalClient
+}
+
+// NewSettingsInvalidations creates a broadcaster on client
+func NewSettingsInvalidations(client goredis.UniversalClient) *SettingsInvalidations {
+	return &SettingsInvalidations{client: client}
+}
+
+var _ settings.Invalidator = (*SettingsInvalidations)(nil)
+
+// Invalidate announces that tenantID's settings changed
+func (s *SettingsInvalidations) Invalidate(ctx context.Context, tenantID string) error {
+	if err := s.client.Publish(ctx, settingsChannel, tenantID).Err(); err != nil {
+		return fmt.Errorf("publish settings invalidation: %w", err)
+	}
+	return nil
+}
+
+// Run calls invalidate with the id of every tenant announced by any
+// replica, including this one, until ctx ends
+func (s *SettingsInvalidations) Run(ctx context.Context, invalidate func(tenantID string)) error {
+	ps := s.client.Subscribe(ctx, settingsChannel)
+	defer ps.Close()
+	// Wait for the subscription so announcements made right after Run
+	// starts are not missed
+	if _, err := ps.Receive(ctx); err != nil {
+		return fmt.Errorf("subscribe to settings invalidations: %w", err)
+	}
+
+	ch := ps.Channel()
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			invalidate(msg.Payload)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
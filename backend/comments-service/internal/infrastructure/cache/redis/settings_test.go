@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestSettingsInvalidations(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replica := NewSettingsInvalidations(client)
+	got := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() { done <- replica.Run(ctx, func(id string) { got <- id }) }()
+
+	// The subscription is set up asynchronously; publish until it is seen
+	other := NewSettingsInvalidations(client)
+	deadline := time.After(2 * time.Second)
+	for received := false; !received; {
+		if err := other.Invalidate(ctx, "tenant-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		select {
+		case id := <-got:
+			if id != "tenant-1" {
+				t.Errorf("expected tenant-1, got %s", id)
+			}
+			received = true
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("invalidation was not received")
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+}
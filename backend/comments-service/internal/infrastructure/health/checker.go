@@ -0,0 +1,142 @@
+// Package health probes the service's dependencies for the readiness
+// endpoint.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status summarizes a component or the whole service
+type Status string
+
+const (
+	StatusOK Status = "ok"
+	// StatusDegraded means a non-critical component is failing, or a
+	// critical one answers but reports a warning
+	StatusDegraded    Status = "degraded"
+	StatusUnavailable Status = "unavailable"
+)
+
+// Probe checks one dependency. It may return details to include in the
+// report alongside its result.
+type Probe func(ctx context.Context) (details any, err error)
+
+// Degraded wraps err so its component is reported degraded rather than
+// unavailable: the dependency answers, but needs attention
+func Degraded(err error) error {
+	return degradedError{err}
+}
+
+type degradedError struct{ error }
+
+func (e degradedError) Unwrap() error { return e.error }
+
+// Check is a named probe. A failing critical check makes the service
+// unavailable; a failing non-critical one only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    Probe
+}
+
+// ComponentReport is the outcome of one check
+type ComponentReport struct {
+	Status    Status `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+	Details   any    `json:"details,omitempty"`
+}
+
+// Report is the outcome of all checks
+type Report struct {
+	Status     Status                     `json:"status"`
+	Components map[string]ComponentReport `json:"components"`
+	CheckedAt  time.Time                  `json:"checked_at"`
+}
+
+// Checker runs every check concurrently, each under its own timeout
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewChecker creates a checker that gives each probe at most timeout
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// SetDraining marks the service as shutting down so readiness fails and
+// load balancers stop routing new requests while in-flight ones finish
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Check probes every dependency and aggregates the results
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]ComponentReport, len(c.checks)),
+		CheckedAt:  time.Now().UTC(),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := c.run(ctx, check)
+			mu.Lock()
+			report.Components[check.Name] = result
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Components {
+		if result.Status == StatusOK {
+			continue
+		}
+		if result.Critical && result.Status == StatusUnavailable {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	if c.draining.Load() {
+		report.Status = StatusUnavailable
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) ComponentReport {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := check.Probe(ctx)
+	result := ComponentReport{
+		Status:    StatusOK,
+		Critical:  check.Critical,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Details:   details,
+	}
+	var degraded degradedError
+	switch {
+	case errors.As(err, &degraded):
+		result.Status = StatusDegraded
+		result.Error = err.Error()
+	case err != nil:
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+	return result
+}
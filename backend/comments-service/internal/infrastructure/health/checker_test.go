@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func ok(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func failing(ctx context.Context) (any, error) {
+	return nil, errors.New("connection refused")
+}
+
+func warning(ctx context.Context) (any, error) {
+	return []string{"Cache Hit Ratio WARNING"}, Degraded(errors.New("cache hit ratio is low"))
+}
+
+func slow(ctx context.Context) (any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		want   Status
+	}{
+		{"all healthy", []Check{{"db", true, ok}, {"redis", true, ok}}, StatusOK},
+		{"critical failure", []Check{{"db", true, failing}, {"redis", true, ok}}, StatusUnavailable},
+		{"non-critical failure", []Check{{"db", true, ok}, {"replica", false, failing}}, StatusDegraded},
+		{"timeout", []Check{{"broker", true, slow}}, StatusUnavailable},
+		{"critical warning", []Check{{"db", true, warning}, {"redis", true, ok}}, StatusDegraded},
+		{"warning and failure", []Check{{"db", true, warning}, {"redis", true, failing}}, StatusUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewChecker(20*time.Millisecond, tt.checks...).Check(context.Background())
+
+			if report.Status != tt.want {
+				t.Errorf("expected status %s, got %s", tt.want, report.Status)
+			}
+			if len(report.Components) != len(tt.checks) {
+				t.Errorf("expected %d components, got %d", len(tt.checks), len(report.Components))
+			}
+		})
+	}
+}
+
+func TestChecker_ReportsErrorsAndDetails(t *testing.T) {
+	detailed := func(ctx context.Context) (any, error) {
+		return []string{"Cache Hit Ratio OK"}, nil
+	}
+	report := NewChecker(time.Second, Check{"db", true, detailed}, Check{"redis", true, failing}, Check{"replica", false, warning}).Check(context.Background())
+
+	if report.Components["db"].Details == nil {
+		t.Error("expected db details in report")
+	}
+	if report.Components["redis"].Error != "connection refused" {
+		t.Errorf("expected redis error, got %q", report.Components["redis"].Error)
+	}
+	if got := report.Components["replica"]; got.Status != StatusDegraded || got.Error != "cache hit ratio is low" || got.Details == nil {
+		t.Errorf("expected degraded replica with details, got %+v", got)
+	}
+}
+
+func TestChecker_Draining(t *testing.T) {
+	c := NewChecker(time.Second, Check{"db", true, ok})
+	c.SetDraining()
+
+	if got := c.Check(context.Background()).Status; got != StatusUnavailable {
+		t.Errorf("expected draining service to be unavailable, got %s", got)
+	}
+}
@@ -0,0 +1,45 @@
+// Package logging sends slog and standard library log output through one
+// handler whose level and format follow the configuration.
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+)
+
+var level slog.LevelVar
+
+// Configure installs a LOG_FORMAT handler at LOG_LEVEL, writing to stderr,
+// as the default logger. It is called again when the config reloads.
+func Configure(app config.AppConfig) {
+	configure(app, os.Stderr)
+}
+
+func configure(app config.AppConfig, w io.Writer) {
+	level.Set(parseLevel(app.LogLevel))
+	opts := &slog.HandlerOptions{Level: &level}
+
+	var handler slog.Handler
+	if app.LogFormat == "text" {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	slog.SetDefault(slog.New(handler).With("service", app.Name))
+}
+
+func parseLevel(s string) slog.Level {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
@@ -0,0 +1,30 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+)
+
+func TestConfigure(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	var buf bytes.Buffer
+
+	configure(config.AppConfig{Name: "svc", LogLevel: "warn", LogFormat: "json"}, &buf)
+	log.Printf("hidden")
+	slog.Warn("shown")
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), `"msg":"shown"`) {
+		t.Errorf("expected only warnings as JSON, got %s", buf.String())
+	}
+
+	buf.Reset()
+	configure(config.AppConfig{Name: "svc", LogLevel: "info", LogFormat: "text"}, &buf)
+	log.Printf("standard")
+	if !strings.Contains(buf.String(), "msg=standard") || !strings.Contains(buf.String(), "service=svc") {
+		t.Errorf("expected standard log output as text, got %s", buf.String())
+	}
+}
@@ -0,0 +1,113 @@
+// Package rabbitmq holds the broker connection used to publish domain
+// events.
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Client owns one AMQP connection and re-dials it after the broker drops it
+type Client struct {
+	url  string
+	mu   sync.Mutex
+	conn *amqp.Connection
+}
+
+// NewClient creates a client for url. No connection is made until it is
+// first needed.
+func NewClient(url string) *Client {
+	return &Client{url: url}
+}
+
+// SetURL changes the broker URL, for instance after its credentials rotate.
+// The open connection is kept; the new URL is used on the next dial.
+func (c *Client) SetURL(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.url = url
+}
+
+// Channel opens a channel on the current connection, dialing first if the
+// connection is missing or closed
+func (c *Client) Channel() (*amqp.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil || c.conn.IsClosed() {
+		conn, err := amqp.Dial(c.url)
+		if err != nil {
+			return nil, fmt.Errorf("dial rabbitmq: %w", err)
+		}
+		c.conn = conn
+	}
+
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("open channel: %w", err)
+	}
+	return ch, nil
+}
+
+// Publish sends v as a persistent JSON message to queue, declaring the
+// queue as durable first
+func (c *Client) Publish(ctx context.Context, queue string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+	ch, err := c.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare queue %s: %w", queue, err)
+	}
+	err = ch.PublishWithContext(ctx, "", queue, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Body:         body,
+	})
+	if err != nil {
+		return fmt.Errorf("publish to %s: %w", queue, err)
+	}
+	return nil
+}
+
+// Ping verifies the broker accepts a channel within ctx
+func (c *Client) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		ch, err := c.Channel()
+		if err == nil {
+			err = ch.Close()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the connection if one is open
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil || c.conn.IsClosed() {
+		return nil
+	}
+	return c.conn.Close()
+}
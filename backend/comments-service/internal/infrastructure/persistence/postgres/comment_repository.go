@@ -0,0 +1,403 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/pkg/cursor"
+)
+
+const commentColumns = `
+	id::text, tenant_id::text, parent_id::text, depth, path,
+	entity_type, entity_id, author_id::text, author_name, author_email,
+	content, content_format::text, status::text, is_pinned, is_edited,
+	like_count, reply_count, created_at, updated_at, edited_at`
+
+// CommentRepository implements comment.Repository
+type CommentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommentRepository creates a comment repository on pool
+func NewCommentRepository(pool *pgxpool.Pool) *CommentRepository {
+	return &CommentRepository{pool: pool}
+}
+
+var _ comment.Repository = (*CommentRepository)(nil)
+
+// Create inserts c and bumps the parent's reply_count in one transaction.
+// c.ID is used when set, letting callers supply a time-ordered id; otherwise
+// the database generates one. The id and timestamps are written back to c.
+func (r *CommentRepository) Create(ctx context.Context, c *comment.Comment) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO comments (
+				id, tenant_id, parent_id, depth, path, entity_type, entity_id,
+				author_id, author_name, author_email, content, content_format, status
+			) VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v7()), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			RETURNING id::text, created_at, updated_at`,
+			c.ID, c.TenantID, c.ParentID, c.Depth, c.Path, c.EntityType, c.EntityID,
+			c.AuthorID, c.AuthorName, c.AuthorEmail, c.Content, string(c.ContentFormat), string(c.Status),
+		).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return translate(err, "insert comment")
+		}
+
+		if c.ParentID != nil {
+			if _, err := tx.Exec(ctx, `
+				UPDATE comments SET reply_count = reply_count + 1
+				WHERE id = $1 AND tenant_id = $2`,
+				*c.ParentID, c.TenantID,
+			); err != nil {
+				return translate(err, "increment reply count")
+			}
+		}
+		return nil
+	})
+	return translate(err, "create comment")
+}
+
+// GetByID returns a comment that has not been soft-deleted
+func (r *CommentRepository) GetByID(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+commentColumns+`
+		FROM comments
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`,
+		id, tenantID,
+	)
+	return scanComment(row)
+}
+
+// Update replaces the content of a comment and appends the previous
+// revision to comment_edits
+func (r *CommentRepository) Update(ctx context.Context, tenantID, id string, edit comment.Edit) (*comment.Comment, error) {
+	var updated *comment.Comment
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var previous string
+		err := tx.QueryRow(ctx, `
+			SELECT content FROM comments
+			WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+			FOR UPDATE`,
+			id, tenantID,
+		).Scan(&previous)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return comment.ErrNotFound
+			}
+			return translate(err, "lock comment")
+		}
+
+		row := tx.QueryRow(ctx, `
+			UPDATE comments
+			SET content = $3, is_edited = true, edited_at = NOW()
+			WHERE id = $1 AND tenant_id = $2
+			RETURNING `+commentColumns,
+			id, tenantID, edit.Content,
+		)
+		if updated, err = scanComment(row); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO comment_edits (tenant_id, comment_id, previous_content, new_content, edited_by, reason)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			tenantID, id, previous, edit.Content, edit.EditedBy, edit.Reason,
+		); err != nil {
+			return translate(err, "record edit")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, translate(err, "update comment")
+	}
+	return updated, nil
+}
+
+// SoftDeleteTree soft-deletes a comment and its descendants through
+// soft_delete_comment_tree and releases the slot in the parent's reply_count
+func (r *CommentRepository) SoftDeleteTree(ctx context.Context, tenantID, id string) (int, error) {
+	var deleted int
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var parentID *string
+		err := tx.QueryRow(ctx, `
+			SELECT parent_id::text FROM comments
+			WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+			FOR UPDATE`,
+			id, tenantID,
+		).Scan(&parentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return comment.ErrNotFound
+			}
+			return translate(err, "lock comment")
+		}
+
+		if err := tx.QueryRow(ctx, `SELECT soft_delete_comment_tree($1)`, id).Scan(&deleted); err != nil {
+			return translate(err, "soft delete tree")
+		}
+
+		if parentID != nil {
+			if _, err := tx.Exec(ctx, `
+				UPDATE comments SET reply_count = GREATEST(reply_count - 1, 0)
+				WHERE id = $1 AND tenant_id = $2`,
+				*parentID, tenantID,
+			); err != nil {
+				return translate(err, "decrement reply count")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, translate(err, "delete comment")
+	}
+	return deleted, nil
+}
+
+// List returns one keyset page of active comments under an entity
+func (r *CommentRepository) List(ctx context.Context, q comment.ListQuery) ([]*comment.Comment, error) {
+	where := `tenant_id = $1 AND entity_type = $2 AND entity_id = $3
+		AND deleted_at IS NULL AND status = 'ACTIVE'`
+	args := []any{q.TenantID, q.EntityType, q.EntityID}
+	if q.ParentID != nil {
+		args = append(args, *q.ParentID)
+		where += fmt.Sprintf(` AND parent_id = $%d`, len(args))
+	} else {
+		where += ` AND parent_id IS NULL`
+	}
+
+	keys := sortKeys(q.Sort)
+	backward := q.After != nil && q.After.Backward
+	if q.After != nil {
+		var predicate string
+		predicate, args = keysetPredicate(keys, cursorValues(q.Sort, q.After), backward, args)
+		where += ` AND (` + predicate + `)`
+	}
+	args = append(args, q.Limit)
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM comments
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d`,
+		commentColumns, where, orderByKeys(keys, backward), len(args),
+	), args...)
+	if err != nil {
+		return nil, translate(err, "list comments")
+	}
+	defer rows.Close()
+
+	var comments []*comment.Comment
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translate(err, "list comments")
+	}
+
+	// A backward page is read in reverse so the LIMIT keeps the rows
+	// closest to the cursor; flip it back into display order.
+	if backward {
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	}
+	return comments, nil
+}
+
+// Descendants expands each root through get_comment_tree and keeps the
+// live replies down to maxDepth, at most perParent per parent in sort order.
+// Rows are returned shallowest first so callers can attach them in one pass.
+func (r *CommentRepository) Descendants(ctx context.Context, tenantID string, rootIDs []string, maxDepth, perParent int, sort comment.Sort) ([]*comment.Comment, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	order := orderBy(sort)
+	rows, err := r.pool.Query(ctx, `
+		WITH subtree AS (
+			SELECT c.*
+			FROM unnest($2::uuid[]) AS r(id)
+			CROSS JOIN LATERAL get_comment_tree(r.id) AS gt
+			INNER JOIN comments c ON c.id = gt.id
+			WHERE c.id <> r.id
+			  AND c.tenant_id = $1
+			  AND c.deleted_at IS NULL
+			  AND c.status = 'ACTIVE'
+			  AND c.depth <= $3
+		), ranked AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY `+order+`) AS rn
+			FROM subtree
+		)
+		SELECT `+commentColumns+`
+		FROM ranked
+		WHERE rn <= $4
+		ORDER BY depth, `+order,
+		tenantID, rootIDs, maxDepth, perParent,
+	)
+	if err != nil {
+		return nil, translate(err, "load descendants")
+	}
+	defer rows.Close()
+
+	var comments []*comment.Comment
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translate(err, "load descendants")
+	}
+	return comments, nil
+}
+
+// ReplyCounts counts the direct replies that pass the filter of List and
+// Descendants
+func (r *CommentRepository) ReplyCounts(ctx context.Context, tenantID string, parentIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT parent_id, COUNT(*)
+		FROM comments
+		WHERE tenant_id = $1
+		  AND parent_id = ANY($2::uuid[])
+		  AND deleted_at IS NULL
+		  AND status = 'ACTIVE'
+		GROUP BY parent_id`,
+		tenantID, parentIDs,
+	)
+	if err != nil {
+		return nil, translate(err, "count replies")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			parentID string
+			n        int
+		)
+		if err := rows.Scan(&parentID, &n); err != nil {
+			return nil, translate(err, "count replies")
+		}
+		counts[parentID] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translate(err, "count replies")
+	}
+	return counts, nil
+}
+
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+// sortKeys is shared by every query that pages through siblings so that
+// cursors handed out by one query line up with the next. Pinned comments
+// always lead and id breaks ties.
+func sortKeys(s comment.Sort) []sortKey {
+	keys := []sortKey{{"is_pinned", true}}
+	switch s {
+	case comment.SortOldest:
+		return append(keys, sortKey{"created_at", false}, sortKey{"id", false})
+	case comment.SortTop:
+		return append(keys, sortKey{"like_count", true}, sortKey{"created_at", true}, sortKey{"id", true})
+	default:
+		return append(keys, sortKey{"created_at", true}, sortKey{"id", true})
+	}
+}
+
+// cursorValues lines the cursor fields up with sortKeys(s)
+func cursorValues(s comment.Sort, c *cursor.Cursor) []any {
+	if s == comment.SortTop {
+		return []any{c.Pinned, c.Score, c.CreatedAt, c.ID}
+	}
+	return []any{c.Pinned, c.CreatedAt, c.ID}
+}
+
+func orderBy(s comment.Sort) string {
+	return orderByKeys(sortKeys(s), false)
+}
+
+func orderByKeys(keys []sortKey, reverse bool) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		dir := "ASC"
+		if k.desc != reverse {
+			dir = "DESC"
+		}
+		parts[i] = k.column + " " + dir
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keysetPredicate expands "row comes after values in keys order" into
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., flipping each comparison for
+// descending keys and again when paging backward. The values are appended
+// to args and the extended slice is returned.
+func keysetPredicate(keys []sortKey, values []any, backward bool, args []any) (string, []any) {
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		args = append(args, v)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	clauses := make([]string, len(keys))
+	for i, k := range keys {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, keys[j].column+" = "+placeholders[j])
+		}
+		op := ">"
+		if k.desc != backward {
+			op = "<"
+		}
+		terms = append(terms, k.column+" "+op+" "+placeholders[i])
+		clauses[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return strings.Join(clauses, " OR "), args
+}
+
+func scanComment(row pgx.Row) (*comment.Comment, error) {
+	return scanCommentWith(row)
+}
+
+// scanCommentWith scans commentColumns followed by the columns of extra
+func scanCommentWith(row pgx.Row, extra ...any) (*comment.Comment, error) {
+	var (
+		c             comment.Comment
+		contentFormat string
+		status        string
+	)
+	dest := []any{
+		&c.ID, &c.TenantID, &c.ParentID, &c.Depth, &c.Path,
+		&c.EntityType, &c.EntityID, &c.AuthorID, &c.AuthorName, &c.AuthorEmail,
+		&c.Content, &contentFormat, &status, &c.IsPinned, &c.IsEdited,
+		&c.LikeCount, &c.ReplyCount, &c.CreatedAt, &c.UpdatedAt, &c.EditedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, comment.ErrNotFound
+		}
+		return nil, translate(err, "scan comment")
+	}
+	c.ContentFormat = comment.Format(contentFormat)
+	c.Status = comment.Status(status)
+	return &c, nil
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+)
+
+func TestKeysetPredicate(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     comment.Sort
+		backward bool
+		want     string
+	}{
+		{
+			name: "newest forward",
+			sort: comment.SortNewest,
+			want: "(is_pinned < $2) OR (is_pinned = $2 AND created_at < $3) OR (is_pinned = $2 AND created_at = $3 AND id < $4)",
+		},
+		{
+			name: "oldest forward",
+			sort: comment.SortOldest,
+			want: "(is_pinned < $2) OR (is_pinned = $2 AND created_at > $3) OR (is_pinned = $2 AND created_at = $3 AND id > $4)",
+		},
+		{
+			name:     "oldest backward",
+			sort:     comment.SortOldest,
+			backward: true,
+			want:     "(is_pinned > $2) OR (is_pinned = $2 AND created_at < $3) OR (is_pinned = $2 AND created_at = $3 AND id < $4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := sortKeys(tt.sort)
+			values := make([]any, len(keys))
+			got, args := keysetPredicate(keys, values, tt.backward, []any{"tenant"})
+			if got != tt.want {
+				t.Errorf("keysetPredicate() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if len(args) != 1+len(keys) {
+				t.Errorf("expected %d args, got %d", 1+len(keys), len(args))
+			}
+		})
+	}
+}
+
+func TestOrderByKeys(t *testing.T) {
+	keys := sortKeys(comment.SortTop)
+
+	if got, want := orderByKeys(keys, false), "is_pinned DESC, like_count DESC, created_at DESC, id DESC"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := orderByKeys(keys, true), "is_pinned ASC, like_count ASC, created_at ASC, id ASC"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
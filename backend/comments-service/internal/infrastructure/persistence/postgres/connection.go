@@ -0,0 +1,41 @@
+// Package postgres implements the domain repositories on PostgreSQL using
+// pgx connection pools.
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+)
+
+// Connect opens a connection pool for dsn sized from cfg. When password is
+// set it is asked for the password of every new connection, so a rotated
+// password applies without reopening the pool.
+func Connect(ctx context.Context, dsn string, cfg config.DatabaseConfig, password func() string) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse dsn: %w", err)
+	}
+	if cfg.MaxConnections > 0 {
+		poolCfg.MaxConns = int32(cfg.MaxConnections)
+	}
+	if cfg.MaxIdleConns > 0 && cfg.MaxIdleConns <= cfg.MaxConnections {
+		poolCfg.MinConns = int32(cfg.MaxIdleConns)
+	}
+	if password != nil {
+		poolCfg.BeforeConnect = func(ctx context.Context, cc *pgx.ConnConfig) error {
+			cc.Password = password()
+			return nil
+		}
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create pool: %w", err)
+	}
+	return pool, nil
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// SQLSTATEs the translator tells apart
+const (
+	sqlStateNotNullViolation    = "23502"
+	sqlStateForeignKeyViolation = "23503"
+	sqlStateUniqueViolation     = "23505"
+	sqlStateCheckViolation      = "23514"
+	sqlStateRaiseException      = "P0001"
+	sqlStateSerialization       = "40001"
+	sqlStateDeadlock            = "40P01"
+	sqlStateTooManyConnections  = "53300"
+	// sqlClassDataException covers malformed values such as an invalid UUID
+	sqlClassDataException = "22"
+	// sqlClassConnection and sqlClassOperatorIntervention cover lost
+	// connections and a server shutting down
+	sqlClassConnection           = "08"
+	sqlClassOperatorIntervention = "57P"
+)
+
+// constraintMessages says what a violated constraint means to a client.
+// Names follow the migrations; unique constraints declared inline get the
+// <table>_<column>_key name Postgres generates.
+var constraintMessages = map[string]string{
+	// unique
+	"tenants_subdomain_key":           "subdomain is already taken",
+	"api_keys_key_hash_key":           "api key already exists",
+	"unique_users_tenant_email":       "email is already registered",
+	"unique_users_tenant_username":    "username is already taken",
+	"unique_users_oauth":              "oauth account is already linked",
+	"unique_likes_user_comment":       "comment is already liked",
+	"sessions_refresh_token_hash_key": "session already exists",
+
+	// foreign keys
+	"fk_api_keys_tenant":       "tenant not found",
+	"fk_users_tenant":          "tenant not found",
+	"fk_comments_tenant":       "tenant not found",
+	"fk_comments_parent":       "parent comment not found",
+	"fk_comments_author":       "author not found",
+	"fk_comments_moderator":    "moderator not found",
+	"fk_likes_tenant":          "tenant not found",
+	"fk_likes_comment":         "comment not found",
+	"fk_likes_user":            "user not found",
+	"fk_sessions_tenant":       "tenant not found",
+	"fk_sessions_user":         "user not found",
+	"fk_audit_logs_tenant":     "tenant not found",
+	"fk_audit_logs_user":       "user not found",
+	"fk_comment_edits_tenant":  "tenant not found",
+	"fk_comment_edits_comment": "comment not found",
+	"fk_comment_edits_user":    "user not found",
+
+	// checks
+	"tenants_subdomain_length":         "subdomain must be at least 3 characters",
+	"tenants_subdomain_format":         "subdomain may only contain letters, digits and hyphens",
+	"tenants_subdomain_label":          "subdomain must be a lowercase DNS label of at most 63 characters",
+	"tenants_name_not_empty":           "name is required",
+	"tenants_rate_limits_positive":     "rate limits must be positive",
+	"api_keys_key_hash_length":         "api key hash must be 64 characters",
+	"api_keys_scopes_not_empty":        "api key needs at least one scope",
+	"api_keys_expiry_future":           "api key must expire in the future",
+	"api_keys_pepper_version_valid":    "api key pepper version cannot be negative",
+	"users_email_format":               "email is not a valid address",
+	"users_username_format":            "username may only contain letters, digits, underscores and hyphens",
+	"users_username_length":            "username must be between 3 and 100 characters",
+	"users_password_or_oauth":          "user needs a password or an oauth account",
+	"comments_content_length":          "content length is out of range",
+	"comments_depth_nonnegative":       "comment depth cannot be negative",
+	"comments_depth_max":               "comment is nested too deeply",
+	"comments_like_count_nonnegative":  "like count cannot be negative",
+	"comments_reply_count_nonnegative": "reply count cannot be negative",
+	"comments_spam_score_range":        "spam score must be between 0 and 1",
+	"sessions_expires_future":          "session must expire after it is created",
+}
+
+// triggerMessages says what an exception raised by a trigger function
+// means to a client, keyed by the function's name
+var triggerMessages = map[string]string{
+	"validate_tenant_active":       "tenant has been deleted",
+	"prevent_like_deleted_comment": "cannot like a deleted comment",
+}
+
+// translate turns a driver error from op into an AppError so that nothing
+// above the repositories sees pgx or SQLSTATEs: unique violations become
+// Conflict, foreign key misses NotFound, check, not-null and trigger
+// exceptions BadRequest, and serialization failures or deadlocks a
+// retryable Aborted. An unreachable or overloaded server is Unavailable and
+// a query cut off by its deadline a Timeout. Anything else is an internal
+// error. AppErrors and the domain's ErrNotFound values pass through
+// unchanged, so translate can wrap the result of a whole transaction.
+func translate(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) || stderrors.Is(err, comment.ErrNotFound) || stderrors.Is(err, tenant.ErrNotFound) ||
+		stderrors.Is(err, user.ErrNotFound) {
+		return err
+	}
+	cause := fmt.Errorf("%s: %w", op, err)
+
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return errors.Timeout("database did not respond in time", cause).WithMessageID("error.database_timeout", nil)
+	}
+	var connErr *pgconn.ConnectError
+	if stderrors.As(err, &connErr) {
+		return errors.Unavailable("database unavailable", cause).WithMessageID("error.database_unavailable", nil)
+	}
+	var pgErr *pgconn.PgError
+	if !stderrors.As(err, &pgErr) {
+		return errors.InternalServer("database error", cause)
+	}
+
+	var translated *errors.AppError
+	switch {
+	case pgErr.Code == sqlStateUniqueViolation:
+		translated = errors.Conflict(constraintMessage(pgErr, "resource already exists"))
+	case pgErr.Code == sqlStateForeignKeyViolation:
+		translated = errors.NotFound(constraintMessage(pgErr, "referenced resource not found"))
+	case pgErr.Code == sqlStateCheckViolation:
+		translated = errors.BadRequest(constraintMessage(pgErr, "invalid value"))
+	case pgErr.Code == sqlStateNotNullViolation:
+		translated = errors.BadRequest(pgErr.ColumnName + " is required")
+	case pgErr.Code == sqlStateRaiseException:
+		translated = errors.BadRequest(triggerMessage(pgErr))
+	case strings.HasPrefix(pgErr.Code, sqlClassDataException):
+		translated = errors.BadRequest("invalid value")
+	case pgErr.Code == sqlStateSerialization, pgErr.Code == sqlStateDeadlock:
+		return errors.Aborted("request conflicted with a concurrent update, please retry", cause).WithMessageID("error.retry_concurrent_update", nil)
+	case pgErr.Code == sqlStateTooManyConnections,
+		strings.HasPrefix(pgErr.Code, sqlClassConnection),
+		strings.HasPrefix(pgErr.Code, sqlClassOperatorIntervention):
+		return errors.Unavailable("database unavailable", cause).WithMessageID("error.database_unavailable", nil)
+	default:
+		return errors.InternalServer("database error", cause)
+	}
+	translated.Err = cause
+	return translated
+}
+
+func constraintMessage(pgErr *pgconn.PgError, fallback string) string {
+	if msg, ok := constraintMessages[pgErr.ConstraintName]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// triggerMessage finds the raising function in the PL/pgSQL context, as in
+// "PL/pgSQL function prevent_like_deleted_comment() line 9 at RAISE"
+func triggerMessage(pgErr *pgconn.PgError) string {
+	for function, msg := range triggerMessages {
+		if strings.Contains(pgErr.Where, "function "+function+"(") {
+			return msg
+		}
+	}
+	if pgErr.Message == "" {
+		return "request rejected"
+	}
+	return pgErr.Message
+}
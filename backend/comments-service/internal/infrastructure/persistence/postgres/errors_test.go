@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      string
+		status    int
+		message   string
+		retryable bool
+	}{
+		{
+			name:    "unique violation",
+			err:     &pgconn.PgError{Code: "23505", ConstraintName: "unique_likes_user_comment"},
+			code:    errors.ErrCodeConflict,
+			status:  http.StatusConflict,
+			message: "comment is already liked",
+		},
+		{
+			name:    "unknown unique constraint",
+			err:     &pgconn.PgError{Code: "23505", ConstraintName: "some_new_key"},
+			code:    errors.ErrCodeConflict,
+			status:  http.StatusConflict,
+			message: "resource already exists",
+		},
+		{
+			name:    "foreign key miss",
+			err:     &pgconn.PgError{Code: "23503", ConstraintName: "fk_comments_parent"},
+			code:    errors.ErrCodeNotFound,
+			status:  http.StatusNotFound,
+			message: "parent comment not found",
+		},
+		{
+			name:    "check violation",
+			err:     &pgconn.PgError{Code: "23514", ConstraintName: "comments_spam_score_range"},
+			code:    errors.ErrCodeBadRequest,
+			status:  http.StatusBadRequest,
+			message: "spam score must be between 0 and 1",
+		},
+		{
+			name:    "not null violation",
+			err:     &pgconn.PgError{Code: "23502", ColumnName: "author_name"},
+			code:    errors.ErrCodeBadRequest,
+			status:  http.StatusBadRequest,
+			message: "author_name is required",
+		},
+		{
+			name: "trigger exception",
+			err: &pgconn.PgError{
+				Code:    "P0001",
+				Message: "Cannot like a deleted comment",
+				Where:   "PL/pgSQL function prevent_like_deleted_comment() line 9 at RAISE",
+			},
+			code:    errors.ErrCodeBadRequest,
+			status:  http.StatusBadRequest,
+			message: "cannot like a deleted comment",
+		},
+		{
+			name:    "invalid uuid",
+			err:     &pgconn.PgError{Code: "22P02"},
+			code:    errors.ErrCodeBadRequest,
+			status:  http.StatusBadRequest,
+			message: "invalid value",
+		},
+		{
+			name:      "serialization failure",
+			err:       fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40001"}),
+			code:      errors.ErrCodeAborted,
+			status:    http.StatusConflict,
+			retryable: true,
+		},
+		{
+			name:      "deadlock",
+			err:       &pgconn.PgError{Code: "40P01"},
+			code:      errors.ErrCodeAborted,
+			status:    http.StatusConflict,
+			retryable: true,
+		},
+		{
+			name:      "too many connections",
+			err:       &pgconn.PgError{Code: "53300"},
+			code:      errors.ErrCodeUnavailable,
+			status:    http.StatusServiceUnavailable,
+			retryable: true,
+		},
+		{
+			name:      "server shutting down",
+			err:       &pgconn.PgError{Code: "57P01"},
+			code:      errors.ErrCodeUnavailable,
+			status:    http.StatusServiceUnavailable,
+			retryable: true,
+		},
+		{
+			name:      "deadline exceeded",
+			err:       fmt.Errorf("query: %w", context.DeadlineExceeded),
+			code:      errors.ErrCodeTimeout,
+			status:    http.StatusGatewayTimeout,
+			retryable: true,
+		},
+		{
+			name:   "unmapped sqlstate",
+			err:    &pgconn.PgError{Code: "42P01"},
+			code:   errors.ErrCodeInternalServer,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "connection error",
+			err:    stderrors.New("conn closed"),
+			code:   errors.ErrCodeInternalServer,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translate(tt.err, "insert comment")
+
+			var appErr *errors.AppError
+			if !stderrors.As(err, &appErr) {
+				t.Fatalf("expected an AppError, got %T", err)
+			}
+			if appErr.Code != tt.code || appErr.StatusCode != tt.status {
+				t.Errorf("expected %d %s, got %d %s", tt.status, tt.code, appErr.StatusCode, appErr.Code)
+			}
+			if tt.message != "" && appErr.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, appErr.Message)
+			}
+			if appErr.Retryable() != tt.retryable {
+				t.Errorf("expected retryable %v, got %v", tt.retryable, appErr.Retryable())
+			}
+			if !stderrors.Is(err, tt.err) {
+				t.Error("expected the driver error to be kept as the cause")
+			}
+		})
+	}
+}
+
+func TestTranslate_PassesThrough(t *testing.T) {
+	if err := translate(nil, "op"); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if err := translate(comment.ErrNotFound, "op"); err != comment.ErrNotFound {
+		t.Errorf("expected comment.ErrNotFound, got %v", err)
+	}
+	conflict := errors.Conflict("exists")
+	if err := translate(conflict, "op"); err != conflict {
+		t.Errorf("expected the AppError unchanged, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// HealthCheck is one row of check_database_health()
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Details string `json:"details"`
+}
+
+// DatabaseHealth runs check_database_health() from migration 007
+func DatabaseHealth(ctx context.Context, pool *pgxpool.Pool) ([]HealthCheck, error) {
+	rows, err := pool.Query(ctx, `SELECT check_name, status, COALESCE(details, '') FROM check_database_health()`)
+	if err != nil {
+		return nil, fmt.Errorf("check database health: %w", err)
+	}
+	defer rows.Close()
+
+	var checks []HealthCheck
+	for rows.Next() {
+		var h HealthCheck
+		if err := rows.Scan(&h.Name, &h.Status, &h.Details); err != nil {
+			return nil, fmt.Errorf("scan health check: %w", err)
+		}
+		checks = append(checks, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("check database health: %w", err)
+	}
+	return checks, nil
+}
+
+// Warnings returns an error naming the checks that report WARNING, or nil
+// when there are none. CAUTION rows are only reported in the details.
+func Warnings(checks []HealthCheck) error {
+	var warned []string
+	for _, h := range checks {
+		if h.Status == "WARNING" {
+			warned = append(warned, fmt.Sprintf("%s (%s)", h.Name, h.Details))
+		}
+	}
+	if len(warned) == 0 {
+		return nil
+	}
+	return fmt.Errorf("database health warnings: %s", strings.Join(warned, ", "))
+}
@@ -0,0 +1,17 @@
+package postgres
+
+import "testing"
+
+func TestWarnings(t *testing.T) {
+	checks := []HealthCheck{
+		{Name: "Connection Usage", Status: "CAUTION", Details: "85 / 100"},
+		{Name: "Cache Hit Ratio", Status: "WARNING", Details: "82.5%"},
+	}
+	err := Warnings(checks)
+	if err == nil || err.Error() != "database health warnings: Cache Hit Ratio (82.5%)" {
+		t.Errorf("expected the WARNING row, got %v", err)
+	}
+	if err := Warnings(checks[:1]); err != nil {
+		t.Errorf("expected CAUTION rows to pass, got %v", err)
+	}
+}
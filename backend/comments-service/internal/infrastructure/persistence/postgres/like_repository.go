@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+)
+
+// LikeRepository implements like.Repository. like_count only changes
+// through increment_comment_likes and decrement_comment_likes, in the
+// transaction that inserts or deletes the like.
+type LikeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLikeRepository creates a like repository on pool
+func NewLikeRepository(pool *pgxpool.Pool) *LikeRepository {
+	return &LikeRepository{pool: pool}
+}
+
+var _ like.Repository = (*LikeRepository)(nil)
+
+// Add inserts the like, or changes the reaction of the existing one. Only an
+// insert bumps like_count. The comment row is locked first so concurrent
+// likes count in order, and comment.ErrNotFound is returned for comments
+// that are missing, deleted or of another tenant.
+func (r *LikeRepository) Add(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (int, bool, error) {
+	var (
+		count    int
+		inserted bool
+	)
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := lockComment(ctx, tx, tenantID, commentID, &count); err != nil {
+			return err
+		}
+
+		// xmax is only zero for a row this statement inserted
+		err := tx.QueryRow(ctx, `
+			INSERT INTO likes (tenant_id, comment_id, user_id, reaction)
+			VALUES ($1, $2, $3, $4::reaction_type)
+			ON CONFLICT ON CONSTRAINT unique_likes_user_comment
+			DO UPDATE SET reaction = EXCLUDED.reaction
+			RETURNING xmax = 0`,
+			tenantID, commentID, userID, string(reaction),
+		).Scan(&inserted)
+		if err != nil {
+			return translate(err, "insert like")
+		}
+		if !inserted {
+			return nil
+		}
+		if err := tx.QueryRow(ctx, `SELECT increment_comment_likes($1)`, commentID).Scan(&count); err != nil {
+			return translate(err, "increment like count")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, false, translate(err, "add like")
+	}
+	return count, inserted, nil
+}
+
+// Remove deletes the like and, when there was one, lowers like_count
+func (r *LikeRepository) Remove(ctx context.Context, tenantID, commentID, userID string) (int, bool, error) {
+	var (
+		count   int
+		removed bool
+	)
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := lockComment(ctx, tx, tenantID, commentID, &count); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			DELETE FROM likes
+			WHERE tenant_id = $1 AND comment_id = $2 AND user_id = $3`,
+			tenantID, commentID, userID,
+		)
+		if err != nil {
+			return translate(err, "delete like")
+		}
+		if removed = tag.RowsAffected() > 0; !removed {
+			return nil
+		}
+		if err := tx.QueryRow(ctx, `SELECT decrement_comment_likes($1)`, commentID).Scan(&count); err != nil {
+			return translate(err, "decrement like count")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, false, translate(err, "remove like")
+	}
+	return count, removed, nil
+}
+
+// lockComment locks a live comment of the tenant for the rest of tx and
+// reads its like count
+func lockComment(ctx context.Context, tx pgx.Tx, tenantID, commentID string, count *int) error {
+	err := tx.QueryRow(ctx, `
+		SELECT like_count
+		FROM comments
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+		FOR UPDATE`,
+		commentID, tenantID,
+	).Scan(count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return comment.ErrNotFound
+	}
+	return translate(err, "lock comment")
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/moderation"
+)
+
+const moderationColumns = commentColumns + `,
+	flagged_count, moderated_by::text, moderation_note`
+
+// ModerationRepository implements moderation.Repository on the comments
+// table
+type ModerationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewModerationRepository creates a moderation repository on pool
+func NewModerationRepository(pool *pgxpool.Pool) *ModerationRepository {
+	return &ModerationRepository{pool: pool}
+}
+
+var _ moderation.Repository = (*ModerationRepository)(nil)
+
+// Queue reads the queue in the order of idx_comments_moderation_queue
+func (r *ModerationRepository) Queue(ctx context.Context, tenantID string, limit int) ([]*moderation.Item, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+moderationColumns+`
+		FROM comments
+		WHERE tenant_id = $1 AND status IN ('FLAGGED', 'PENDING') AND deleted_at IS NULL
+		ORDER BY flagged_count DESC, created_at DESC
+		LIMIT $2`,
+		tenantID, limit,
+	)
+	if err != nil {
+		return nil, translate(err, "list moderation queue")
+	}
+	defer rows.Close()
+
+	var items []*moderation.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translate(err, "list moderation queue")
+	}
+	return items, nil
+}
+
+// Flag raises flagged_count; comments already held or rejected keep their
+// status
+func (r *ModerationRepository) Flag(ctx context.Context, tenantID, id string) (*moderation.Item, error) {
+	row := r.pool.QueryRow(ctx, `
+		UPDATE comments
+		SET flagged_count = flagged_count + 1,
+		    status = CASE WHEN status = 'ACTIVE' THEN 'FLAGGED'::comment_status ELSE status END
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+		RETURNING `+moderationColumns,
+		id, tenantID,
+	)
+	return scanItem(row)
+}
+
+// Decide locks the comment to check it is still queued, so two moderators
+// deciding at once cannot both succeed
+func (r *ModerationRepository) Decide(ctx context.Context, tenantID, id string, rv moderation.Review) (*moderation.Item, error) {
+	var item *moderation.Item
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var status string
+		err := tx.QueryRow(ctx, `
+			SELECT status::text FROM comments
+			WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+			FOR UPDATE`,
+			id, tenantID,
+		).Scan(&status)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return comment.ErrNotFound
+		}
+		if err != nil {
+			return translate(err, "lock comment")
+		}
+		if s := comment.Status(status); s != comment.StatusFlagged && s != comment.StatusPending {
+			return moderation.ErrNotQueued
+		}
+
+		item, err = scanItem(tx.QueryRow(ctx, `
+			UPDATE comments
+			SET status = $3::comment_status, moderated_by = $4, moderation_note = $5
+			WHERE id = $1 AND tenant_id = $2
+			RETURNING `+moderationColumns,
+			id, tenantID, string(rv.Decision.Status()), rv.ModeratorID, rv.Note,
+		))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, moderation.ErrNotQueued) {
+			return nil, err
+		}
+		return nil, translate(err, "moderate comment")
+	}
+	return item, nil
+}
+
+func scanItem(row pgx.Row) (*moderation.Item, error) {
+	var item moderation.Item
+	c, err := scanCommentWith(row, &item.FlaggedCount, &item.ModeratedBy, &item.ModerationNote)
+	if err != nil {
+		return nil, err
+	}
+	item.Comment = c
+	return &item, nil
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+// TenantRepository implements tenant.Repository
+type TenantRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTenantRepository creates a tenant repository on pool
+func NewTenantRepository(pool *pgxpool.Pool) *TenantRepository {
+	return &TenantRepository{pool: pool}
+}
+
+var _ tenant.Repository = (*TenantRepository)(nil)
+
+// FindByAPIKeyHash applies the same validity rules as is_api_key_valid and
+// returns the owning tenant together with the key's scopes. Key hashes are
+// unique, so at most one of hashes can match.
+func (r *TenantRepository) FindByAPIKeyHash(ctx context.Context, hashes []string) (*tenant.Tenant, string, error) {
+	var (
+		t       tenant.Tenant
+		plan    string
+		status  string
+		matched string
+	)
+	err := r.pool.QueryRow(ctx, `
+		SELECT t.id::text, t.name, t.subdomain, t.plan::text, t.status::text,
+		       t.rate_limit_per_minute, t.rate_limit_per_hour,
+		       t.features, t.settings, ak.scopes, ak.key_hash
+		FROM api_keys ak
+		INNER JOIN tenants t ON ak.tenant_id = t.id
+		WHERE ak.key_hash = ANY($1)
+		  AND ak.is_active
+		  AND ak.revoked_at IS NULL
+		  AND (ak.expires_at IS NULL OR ak.expires_at > NOW())
+		  AND t.deleted_at IS NULL`,
+		hashes,
+	).Scan(
+		&t.ID, &t.Name, &t.Subdomain, &plan, &status,
+		&t.RateLimitPerMinute, &t.RateLimitPerHour,
+		&t.Features, &t.Settings, &t.Scopes, &matched,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", tenant.ErrNotFound
+		}
+		return nil, "", translate(err, "find tenant by api key")
+	}
+	t.Plan = tenant.Plan(plan)
+	t.Status = tenant.Status(status)
+	return &t, matched, nil
+}
+
+// RehashAPIKey moves a key to another pepper. A key already rehashed by a
+// concurrent request no longer matches oldHash and is left alone.
+func (r *TenantRepository) RehashAPIKey(ctx context.Context, oldHash, newHash string, pepperVersion int) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE api_keys
+		SET key_hash = $2, pepper_version = $3
+		WHERE key_hash = $1`,
+		oldHash, newHash, pepperVersion,
+	)
+	if err != nil {
+		return translate(err, "rehash api key")
+	}
+	return nil
+}
+
+var _ tenant.SubdomainRepository = (*TenantRepository)(nil)
+
+// TakenSubdomains checks the candidates in one query. Soft-deleted tenants
+// keep their subdomain, since the UNIQUE constraint still covers them.
+func (r *TenantRepository) TakenSubdomains(ctx context.Context, candidates []string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT subdomain FROM tenants WHERE subdomain = ANY($1)`, candidates)
+	if err != nil {
+		return nil, translate(err, "find taken subdomains")
+	}
+	taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, translate(err, "find taken subdomains")
+	}
+	return taken, nil
+}
+
+var _ settings.Repository = (*TenantRepository)(nil)
+
+// UpdateSettings merges the patches into tenants.features and
+// tenants.settings with the jsonb || operator, leaving other keys as they are
+func (r *TenantRepository) UpdateSettings(ctx context.Context, tenantID string, features, values json.RawMessage) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE tenants
+		SET features = features || $2::jsonb,
+		    settings = settings || $3::jsonb
+		WHERE id = $1 AND deleted_at IS NULL`,
+		tenantID, jsonPatch(features), jsonPatch(values),
+	)
+	if err != nil {
+		return translate(err, "update tenant settings")
+	}
+	if tag.RowsAffected() == 0 {
+		return tenant.ErrNotFound
+	}
+	return nil
+}
+
+// jsonPatch treats an empty patch as one changing nothing
+func jsonPatch(raw json.RawMessage) string {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return "{}"
+	}
+	return string(raw)
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+)
+
+// UserRepository implements user.Repository
+type UserRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserRepository creates a user repository on pool
+func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
+	return &UserRepository{pool: pool}
+}
+
+var _ user.Repository = (*UserRepository)(nil)
+
+// Preferences decodes users.preferences over the column defaults, so keys
+// missing from older rows keep their default
+func (r *UserRepository) Preferences(ctx context.Context, tenantID, userID string) (*user.Preferences, error) {
+	var raw []byte
+	err := r.pool.QueryRow(ctx, `
+		SELECT preferences
+		FROM users
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`,
+		userID, tenantID,
+	).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, user.ErrNotFound
+		}
+		return nil, translate(err, "load user preferences")
+	}
+
+	prefs := user.DefaultPreferences()
+	if err := json.Unmarshal(raw, &prefs); err != nil {
+		return nil, translate(err, "decode user preferences")
+	}
+	return &prefs, nil
+}
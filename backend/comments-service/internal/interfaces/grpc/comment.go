@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commentsv1 "github.com/ayushvyasgit/comments-service/api/proto/comments/v1"
+	appcomment "github.com/ayushvyasgit/comments-service/internal/application/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// CommentService is the application service used by CommentServer
+type CommentService interface {
+	Create(ctx context.Context, in appcomment.CreateInput) (*comment.Comment, error)
+	Get(ctx context.Context, tenantID, id string) (*comment.Comment, error)
+	Update(ctx context.Context, in appcomment.UpdateInput) (*comment.Comment, error)
+	Delete(ctx context.Context, tenantID, id string) (int, error)
+	List(ctx context.Context, in appcomment.ListInput) (*appcomment.ListResult, error)
+	Tree(ctx context.Context, in appcomment.TreeInput) (*appcomment.Tree, error)
+}
+
+// CommentServer implements commentsv1.CommentServiceServer
+type CommentServer struct {
+	commentsv1.UnimplementedCommentServiceServer
+	service CommentService
+}
+
+// NewCommentServer creates a comment server
+func NewCommentServer(service CommentService) *CommentServer {
+	return &CommentServer{service: service}
+}
+
+// CreateComment posts a comment or reply
+func (s *CommentServer) CreateComment(ctx context.Context, req *commentsv1.CreateCommentRequest) (*commentsv1.Comment, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(req.ParentId, "parent_id"); err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.AuthorId, "author_id"); err != nil {
+		return nil, err
+	}
+
+	created, err := s.service.Create(ctx, appcomment.CreateInput{
+		TenantID:      t.ID,
+		EntityType:    req.EntityType,
+		EntityID:      req.EntityId,
+		ParentID:      req.ParentId,
+		AuthorID:      req.AuthorId,
+		AuthorName:    req.AuthorName,
+		AuthorEmail:   req.AuthorEmail,
+		Content:       req.Content,
+		ContentFormat: comment.Format(req.ContentFormat),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toComment(created), nil
+}
+
+// GetComment returns a single live comment
+func (s *CommentServer) GetComment(ctx context.Context, req *commentsv1.GetCommentRequest) (*commentsv1.Comment, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.Id, "id"); err != nil {
+		return nil, err
+	}
+
+	found, err := s.service.Get(ctx, t.ID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toComment(found), nil
+}
+
+// UpdateComment replaces a comment's content
+func (s *CommentServer) UpdateComment(ctx context.Context, req *commentsv1.UpdateCommentRequest) (*commentsv1.Comment, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.Id, "id"); err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.EditedBy, "edited_by"); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.service.Update(ctx, appcomment.UpdateInput{
+		TenantID: t.ID,
+		ID:       req.Id,
+		Content:  req.Content,
+		EditedBy: req.EditedBy,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toComment(updated), nil
+}
+
+// DeleteComment soft deletes a comment and its replies
+func (s *CommentServer) DeleteComment(ctx context.Context, req *commentsv1.DeleteCommentRequest) (*commentsv1.DeleteCommentResponse, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.Id, "id"); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.service.Delete(ctx, t.ID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &commentsv1.DeleteCommentResponse{Deleted: int32(deleted)}, nil
+}
+
+// ListComments returns one page of comments
+func (s *CommentServer) ListComments(ctx context.Context, req *commentsv1.ListCommentsRequest) (*commentsv1.ListCommentsResponse, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(req.ParentId, "parent_id"); err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.List(ctx, appcomment.ListInput{
+		TenantID:   t.ID,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityId,
+		ParentID:   req.ParentId,
+		Sort:       fromSort(req.Sort),
+		Cursor:     req.Cursor,
+		Limit:      int(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &commentsv1.ListCommentsResponse{
+		Comments:   make([]*commentsv1.Comment, 0, len(result.Comments)),
+		Limit:      int32(result.Limit),
+		NextCursor: result.Next,
+		PrevCursor: result.Prev,
+	}
+	for _, c := range result.Comments {
+		resp.Comments = append(resp.Comments, toComment(c))
+	}
+	return resp, nil
+}
+
+// GetCommentTree returns a nested thread
+func (s *CommentServer) GetCommentTree(ctx context.Context, req *commentsv1.GetCommentTreeRequest) (*commentsv1.CommentTree, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(req.RootId, "root_id"); err != nil {
+		return nil, err
+	}
+
+	tree, err := s.service.Tree(ctx, appcomment.TreeInput{
+		TenantID:     t.ID,
+		EntityType:   req.EntityType,
+		EntityID:     req.EntityId,
+		RootID:       req.RootId,
+		Continuation: req.Continuation,
+		Sort:         fromSort(req.Sort),
+		Limit:        int(req.Limit),
+		Depth:        int(req.Depth),
+		RepliesLimit: int(req.RepliesLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &commentsv1.CommentTree{
+		Nodes: toNodes(tree.Nodes),
+		More:  toMore(tree.More),
+	}, nil
+}
+
+func tenantFrom(ctx context.Context) (*tenant.Tenant, error) {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, errors.Unauthorized("missing tenant")
+	}
+	return t, nil
+}
+
+// validUUID rejects a malformed id before it reaches the database. A nil
+// optional field is valid.
+func validUUID(id *string, field string) error {
+	if id == nil {
+		return nil
+	}
+	if _, err := uuid.Parse(*id); err != nil {
+		return errors.BadRequest("invalid " + field)
+	}
+	return nil
+}
+
+func fromSort(s commentsv1.Sort) comment.Sort {
+	switch s {
+	case commentsv1.Sort_SORT_OLD:
+		return comment.SortOldest
+	case commentsv1.Sort_SORT_TOP:
+		return comment.SortTop
+	default:
+		return comment.SortNewest
+	}
+}
+
+func toComment(c *comment.Comment) *commentsv1.Comment {
+	return &commentsv1.Comment{
+		Id:            c.ID,
+		ParentId:      c.ParentID,
+		Depth:         int32(c.Depth),
+		EntityType:    c.EntityType,
+		EntityId:      c.EntityID,
+		AuthorId:      c.AuthorID,
+		AuthorName:    c.AuthorName,
+		Content:       c.Content,
+		ContentFormat: string(c.ContentFormat),
+		Status:        string(c.Status),
+		IsPinned:      c.IsPinned,
+		IsEdited:      c.IsEdited,
+		LikeCount:     int32(c.LikeCount),
+		ReplyCount:    int32(c.ReplyCount),
+		CreatedAt:     timestamppb.New(c.CreatedAt),
+		UpdatedAt:     timestamppb.New(c.UpdatedAt),
+		EditedAt:      toTimestamp(c.EditedAt),
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toNodes(nodes []*appcomment.TreeNode) []*commentsv1.CommentNode {
+	out := make([]*commentsv1.CommentNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, &commentsv1.CommentNode{
+			Comment: toComment(n.Comment),
+			Replies: toNodes(n.Replies),
+			More:    toMore(n.More),
+		})
+	}
+	return out
+}
+
+func toMore(m *appcomment.More) *commentsv1.More {
+	if m == nil {
+		return nil
+	}
+	return &commentsv1.More{Count: int32(m.Count), Continuation: m.Continuation}
+}
@@ -0,0 +1,208 @@
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/ratelimit"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// APIKeyMetadata is the metadata key carrying the tenant API key. gRPC
+// lower-cases keys, so this matches the X-API-Key header of the HTTP API.
+const APIKeyMetadata = "x-api-key"
+
+// AuthInterceptor resolves the tenant from the API key metadata and stores
+// it in the call context, rejecting calls without a valid key
+func AuthInterceptor(auth *apptenant.Authenticator) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		var apiKey string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(APIKeyMetadata); len(values) > 0 {
+				apiKey = values[0]
+			}
+		}
+
+		t, err := auth.Authenticate(ctx, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return handler(tenant.NewContext(ctx, t), req)
+	}
+}
+
+// RateLimitInterceptor counts the calls of the tenant resolved by
+// AuthInterceptor against the same per-minute and per-hour quotas as the
+// HTTP API, failing calls with ResourceExhausted and a retry delay once
+// either is spent. limits is read per call so reloaded limits apply
+// immediately. When the counter fails, calls are let through.
+func RateLimitInterceptor(counter ratelimit.Counter, limits func() config.RateLimitConfig) grpclib.UnaryServerInterceptor {
+	return rateLimitInterceptor(counter, limits, time.Now)
+}
+
+func rateLimitInterceptor(counter ratelimit.Counter, limits func() config.RateLimitConfig, now func() time.Time) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		cfg := limits()
+		t, ok := tenant.FromContext(ctx)
+		if !cfg.Enabled || !ok {
+			return handler(ctx, req)
+		}
+
+		q, ok, err := ratelimit.Take(ctx, counter, t.ID, ratelimit.For(t, cfg.PerMinute, cfg.PerHour), now())
+		if err != nil {
+			log.Printf("rate limit of tenant %s not applied: %v", t.ID, err)
+			return handler(ctx, req)
+		}
+		if !ok {
+			return nil, errors.RateLimited("rate limit exceeded", errors.RateLimit(q))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// SettingsInterceptor stores the settings of the tenant resolved by
+// AuthInterceptor in the call context, for settings.For, as the
+// TenantSettings middleware does for HTTP. They come from cache, which may
+// be nil to decode them on every call.
+func SettingsInterceptor(cache *apptenant.SettingsCache) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		t, ok := tenant.FromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+		return handler(settings.NewContext(ctx, cache.Resolve(t)), req)
+	}
+}
+
+// FeatureGate is the tenant feature a service needs
+type FeatureGate struct {
+	Enabled func(settings.Features) bool
+	// Err is returned while the feature is off
+	Err *errors.AppError
+}
+
+// FeatureInterceptor rejects calls to a service in gates while the plan or
+// settings of the tenant turn its feature off, like the HTTP handlers of
+// the same features. gates is keyed by full service name, as in
+// "comments.v1.LikeService"; other services are not gated.
+func FeatureInterceptor(gates map[string]FeatureGate) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		service, _, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+		gate, ok := gates[service]
+		if ok && !gate.Enabled(settings.For(ctx).Features) {
+			return nil, gate.Err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryInterceptor turns a panic in a handler or inner interceptor into
+// an Internal status, logging the panic and its stack, so one bad call does
+// not take the server down
+func RecoveryInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (resp any, err error) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			slog.Error("panic while serving call",
+				"method", info.FullMethod,
+				"panic", rec,
+				"stack", string(debug.Stack()),
+			)
+			resp, err = nil, status.Error(codes.Internal, "internal server error")
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// ErrorInterceptor converts AppErrors returned by handlers and inner
+// interceptors into gRPC statuses
+func ErrorInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return resp, nil
+	}
+}
+
+// codeFor maps the ErrCode* constants of pkg/errors onto gRPC codes
+var codeFor = map[string]codes.Code{
+	errors.ErrCodeNotFound:          codes.NotFound,
+	errors.ErrCodeBadRequest:        codes.InvalidArgument,
+	errors.ErrCodeValidation:        codes.InvalidArgument,
+	errors.ErrCodeUnauthorized:      codes.Unauthenticated,
+	errors.ErrCodeForbidden:         codes.PermissionDenied,
+	errors.ErrCodeConflict:          codes.AlreadyExists,
+	errors.ErrCodeAborted:           codes.Aborted,
+	errors.ErrCodeRateLimitExceeded: codes.ResourceExhausted,
+	errors.ErrCodePayloadTooLarge:   codes.ResourceExhausted,
+	errors.ErrCodeInternalServer:    codes.Internal,
+	errors.ErrCodeUnavailable:       codes.Unavailable,
+	errors.ErrCodeTimeout:           codes.DeadlineExceeded,
+}
+
+// toStatus renders err as a gRPC status. Only the AppError message is sent;
+// wrapped causes stay on the server like they do for HTTP.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if stderrors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) {
+		return status.Error(codes.Internal, "internal server error")
+	}
+	code, ok := codeFor[appErr.Code]
+	if !ok {
+		code = codes.Unknown
+	}
+	st := status.New(code, appErr.Message)
+
+	// Field violations and retry hints travel as the standard
+	// BadRequest and RetryInfo details
+	var details []protoadapt.MessageV1
+	if len(appErr.Errors) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(appErr.Errors))
+		for i, fe := range appErr.Errors {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: fe.Path, Description: fe.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if d := appErr.RetryAfter(); appErr.Retryable() && d > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(d)})
+	}
+	if len(details) == 0 {
+		return st.Err()
+	}
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
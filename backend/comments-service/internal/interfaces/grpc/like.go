@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+
+	commentsv1 "github.com/ayushvyasgit/comments-service/api/proto/comments/v1"
+	applike "github.com/ayushvyasgit/comments-service/internal/application/like"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+)
+
+// LikeService is the application service used by LikeServer
+type LikeService interface {
+	Like(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (*applike.Result, error)
+	Unlike(ctx context.Context, tenantID, commentID, userID string) (*applike.Result, error)
+}
+
+// LikeServer implements commentsv1.LikeServiceServer
+type LikeServer struct {
+	commentsv1.UnimplementedLikeServiceServer
+	service LikeService
+}
+
+// NewLikeServer creates a like server
+func NewLikeServer(service LikeService) *LikeServer {
+	return &LikeServer{service: service}
+}
+
+// reactions maps the proto enum onto the reaction_type values; an
+// unspecified reaction is a like
+var reactions = map[commentsv1.Reaction]like.Reaction{
+	commentsv1.Reaction_REACTION_UNSPECIFIED: like.ReactionLike,
+	commentsv1.Reaction_REACTION_LIKE:        like.ReactionLike,
+	commentsv1.Reaction_REACTION_LOVE:        like.ReactionLove,
+	commentsv1.Reaction_REACTION_LAUGH:       like.ReactionLaugh,
+	commentsv1.Reaction_REACTION_WOW:         like.ReactionWow,
+	commentsv1.Reaction_REACTION_SAD:         like.ReactionSad,
+	commentsv1.Reaction_REACTION_ANGRY:       like.ReactionAngry,
+}
+
+// LikeComment records a user's reaction to a comment
+func (s *LikeServer) LikeComment(ctx context.Context, req *commentsv1.LikeCommentRequest) (*commentsv1.LikeCount, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.CommentId, "comment_id"); err != nil {
+		return nil, err
+	}
+
+	// Unknown enum numbers map to "" and fail validation in the service
+	res, err := s.service.Like(ctx, t.ID, req.CommentId, req.UserId, reactions[req.Reaction])
+	if err != nil {
+		return nil, err
+	}
+	return toLikeCount(res), nil
+}
+
+// UnlikeComment removes a user's reaction to a comment
+func (s *LikeServer) UnlikeComment(ctx context.Context, req *commentsv1.UnlikeCommentRequest) (*commentsv1.LikeCount, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.CommentId, "comment_id"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.service.Unlike(ctx, t.ID, req.CommentId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return toLikeCount(res), nil
+}
+
+func toLikeCount(r *applike.Result) *commentsv1.LikeCount {
+	return &commentsv1.LikeCount{CommentId: r.CommentID, LikeCount: int32(r.LikeCount)}
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+
+	commentsv1 "github.com/ayushvyasgit/comments-service/api/proto/comments/v1"
+	appmoderation "github.com/ayushvyasgit/comments-service/internal/application/moderation"
+	"github.com/ayushvyasgit/comments-service/internal/domain/moderation"
+)
+
+// ModerationService is the application service used by ModerationServer
+type ModerationService interface {
+	Queue(ctx context.Context, tenantID string, limit int) ([]*moderation.Item, error)
+	Flag(ctx context.Context, tenantID, id string) (*moderation.Item, error)
+	Moderate(ctx context.Context, in appmoderation.ModerateInput) (*moderation.Item, error)
+}
+
+// ModerationServer implements commentsv1.ModerationServiceServer
+type ModerationServer struct {
+	commentsv1.UnimplementedModerationServiceServer
+	service ModerationService
+}
+
+// NewModerationServer creates a moderation server
+func NewModerationServer(service ModerationService) *ModerationServer {
+	return &ModerationServer{service: service}
+}
+
+// decisions maps the proto enum onto moderation decisions; an unspecified
+// decision maps to "" and fails validation in the service
+var decisions = map[commentsv1.Decision]moderation.Decision{
+	commentsv1.Decision_DECISION_APPROVE: moderation.DecisionApprove,
+	commentsv1.Decision_DECISION_REJECT:  moderation.DecisionReject,
+}
+
+// ListModerationQueue returns the comments awaiting review
+func (s *ModerationServer) ListModerationQueue(ctx context.Context, req *commentsv1.ListModerationQueueRequest) (*commentsv1.ListModerationQueueResponse, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.service.Queue(ctx, t.ID, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	resp := &commentsv1.ListModerationQueueResponse{Comments: make([]*commentsv1.ModeratedComment, 0, len(items))}
+	for _, item := range items {
+		resp.Comments = append(resp.Comments, toModeratedComment(item))
+	}
+	return resp, nil
+}
+
+// FlagComment reports a comment
+func (s *ModerationServer) FlagComment(ctx context.Context, req *commentsv1.FlagCommentRequest) (*commentsv1.ModeratedComment, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.Id, "id"); err != nil {
+		return nil, err
+	}
+
+	item, err := s.service.Flag(ctx, t.ID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toModeratedComment(item), nil
+}
+
+// ModerateComment approves or rejects a comment awaiting review
+func (s *ModerationServer) ModerateComment(ctx context.Context, req *commentsv1.ModerateCommentRequest) (*commentsv1.ModeratedComment, error) {
+	t, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validUUID(&req.Id, "id"); err != nil {
+		return nil, err
+	}
+
+	item, err := s.service.Moderate(ctx, appmoderation.ModerateInput{
+		TenantID:    t.ID,
+		ID:          req.Id,
+		Decision:    decisions[req.Decision],
+		ModeratorID: req.ModeratorId,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toModeratedComment(item), nil
+}
+
+func toModeratedComment(item *moderation.Item) *commentsv1.ModeratedComment {
+	return &commentsv1.ModeratedComment{
+		Comment:        toComment(item.Comment),
+		FlaggedCount:   int32(item.FlaggedCount),
+		ModeratedBy:    item.ModeratedBy,
+		ModerationNote: item.ModerationNote,
+	}
+}
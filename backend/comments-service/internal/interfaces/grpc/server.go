@@ -0,0 +1,116 @@
+// Package grpc exposes the application services over gRPC for internal
+// callers, sharing tenant authentication and error codes with the HTTP API.
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+
+	grpclib "google.golang.org/grpc"
+
+	commentsv1 "github.com/ayushvyasgit/comments-service/api/proto/comments/v1"
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/ratelimit"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Dependencies are the services the gRPC server exposes
+type Dependencies struct {
+	Tenants *apptenant.Authenticator
+	// Settings caches the resolved settings of each tenant
+	Settings   *apptenant.SettingsCache
+	Comments   CommentService
+	Likes      LikeService
+	Moderation ModerationService
+	// RateLimits counts the calls of each tenant against the limits
+	// RateLimit returns; either nil disables rate limiting
+	RateLimits ratelimit.Counter
+	RateLimit  func() config.RateLimitConfig
+}
+
+// featureGates are the tenant features each service needs, matching the
+// checks of the HTTP API
+var featureGates = map[string]FeatureGate{
+	commentsv1.LikeService_ServiceDesc.ServiceName: {
+		Enabled: func(f settings.Features) bool { return f.LikesEnabled },
+		Err:     errors.Forbidden("likes are not enabled for this tenant").WithMessageID("likes.disabled", nil),
+	},
+	commentsv1.ModerationService_ServiceDesc.ServiceName: {
+		Enabled: func(f settings.Features) bool { return f.AdvancedModeration },
+		Err:     errors.Forbidden("moderation is not enabled for this tenant").WithMessageID("moderation.disabled", nil),
+	},
+}
+
+// Server is the gRPC listener configured from config.ServerConfig
+type Server struct {
+	addr string
+	srv  *grpclib.Server
+}
+
+// NewServer registers every service on a gRPC server behind the recovery,
+// error, authentication, rate limit, settings and feature interceptors
+func NewServer(cfg *config.Config, deps Dependencies) *Server {
+	interceptors := []grpclib.UnaryServerInterceptor{
+		RecoveryInterceptor(),
+		ErrorInterceptor(),
+		AuthInterceptor(deps.Tenants),
+	}
+	if deps.RateLimits != nil && deps.RateLimit != nil {
+		interceptors = append(interceptors, RateLimitInterceptor(deps.RateLimits, deps.RateLimit))
+	}
+	interceptors = append(interceptors, SettingsInterceptor(deps.Settings), FeatureInterceptor(featureGates))
+
+	srv := grpclib.NewServer(
+		grpclib.MaxRecvMsgSize(cfg.Server.GRPCMaxRecvBytes),
+		grpclib.ChainUnaryInterceptor(interceptors...),
+	)
+	commentsv1.RegisterCommentServiceServer(srv, NewCommentServer(deps.Comments))
+	commentsv1.RegisterLikeServiceServer(srv, NewLikeServer(deps.Likes))
+	commentsv1.RegisterModerationServiceServer(srv, NewModerationServer(deps.Moderation))
+
+	return &Server{addr: cfg.GRPCAddr(), srv: srv}
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Start listens on Addr and serves until Shutdown is called. It returns nil
+// after a clean shutdown.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(lis)
+}
+
+// Serve accepts connections on lis until Shutdown is called
+func (s *Server) Serve(lis net.Listener) error {
+	if err := s.srv.Serve(lis); err != nil && !stderrors.Is(err, grpclib.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for in-flight calls to
+// finish. Calls still running when ctx expires are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
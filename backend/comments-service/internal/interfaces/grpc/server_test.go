@@ -0,0 +1,390 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	commentsv1 "github.com/ayushvyasgit/comments-service/api/proto/comments/v1"
+	appcomment "github.com/ayushvyasgit/comments-service/internal/application/comment"
+	applike "github.com/ayushvyasgit/comments-service/internal/application/like"
+	appmoderation "github.com/ayushvyasgit/comments-service/internal/application/moderation"
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+	"github.com/ayushvyasgit/comments-service/internal/domain/moderation"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/apikey"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+const (
+	testAPIKey    = "test-key"
+	testCommentID = "6f1c2a4e-3b7d-4c1e-9a2b-8d0e5f6a7b8c"
+)
+
+// fakeTenants knows testAPIKey, stored as an unkeyed hash, as a tenant on
+// plan
+type fakeTenants struct {
+	hasher *apikey.Hasher
+	plan   tenant.Plan
+}
+
+func (f fakeTenants) FindByAPIKeyHash(ctx context.Context, hashes []string) (*tenant.Tenant, string, error) {
+	stored := f.hasher.Hash(testAPIKey).Value
+	if !slices.Contains(hashes, stored) {
+		return nil, "", tenant.ErrNotFound
+	}
+	return &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive, Plan: f.plan}, stored, nil
+}
+
+func (fakeTenants) RehashAPIKey(ctx context.Context, oldHash, newHash string, pepperVersion int) error {
+	return nil
+}
+
+type fakeCommentService struct {
+	tenantID string
+	listed   *appcomment.ListInput
+}
+
+func (s *fakeCommentService) Create(ctx context.Context, in appcomment.CreateInput) (*comment.Comment, error) {
+	return &comment.Comment{ID: testCommentID, Content: in.Content}, nil
+}
+
+func (s *fakeCommentService) Get(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	s.tenantID = tenantID
+	if id != testCommentID {
+		return nil, errors.NotFound("comment not found")
+	}
+	return &comment.Comment{ID: id, Content: "Hi", Status: comment.StatusActive}, nil
+}
+
+func (s *fakeCommentService) Update(ctx context.Context, in appcomment.UpdateInput) (*comment.Comment, error) {
+	return &comment.Comment{ID: in.ID, Content: in.Content, IsEdited: true}, nil
+}
+
+func (s *fakeCommentService) Delete(ctx context.Context, tenantID, id string) (int, error) {
+	if id != testCommentID {
+		panic("unexpected comment " + id)
+	}
+	return 3, nil
+}
+
+func (s *fakeCommentService) List(ctx context.Context, in appcomment.ListInput) (*appcomment.ListResult, error) {
+	s.listed = &in
+	return &appcomment.ListResult{Limit: 20, Next: "next"}, nil
+}
+
+func (s *fakeCommentService) Tree(ctx context.Context, in appcomment.TreeInput) (*appcomment.Tree, error) {
+	return &appcomment.Tree{}, nil
+}
+
+func newTestClient(t *testing.T, svc CommentService) commentsv1.CommentServiceClient {
+	t.Helper()
+	return commentsv1.NewCommentServiceClient(newTestConn(t, tenant.PlanFree, Dependencies{Comments: svc}))
+}
+
+// newTestConn serves deps, authenticating testAPIKey as a tenant on plan,
+// and dials the server
+func newTestConn(t *testing.T, plan tenant.Plan, deps Dependencies) *grpclib.ClientConn {
+	t.Helper()
+
+	cfg := &config.Config{Server: config.ServerConfig{GRPCMaxRecvBytes: 1 << 20}}
+	hasher, _ := apikey.NewHasher(nil, true)
+	deps.Tenants = apptenant.NewAuthenticator(fakeTenants{hasher: hasher, plan: plan}, hasher)
+	server := NewServer(cfg, deps)
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func withAPIKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), APIKeyMetadata, key)
+}
+
+func TestServer_Authentication(t *testing.T) {
+	client := newTestClient(t, &fakeCommentService{})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"missing key", context.Background(), codes.Unauthenticated},
+		{"unknown key", withAPIKey("other"), codes.Unauthenticated},
+		{"valid key", withAPIKey(testAPIKey), codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetComment(tt.ctx, &commentsv1.GetCommentRequest{Id: testCommentID})
+			if got := status.Code(err); got != tt.code {
+				t.Errorf("expected code %s, got %s (%v)", tt.code, got, err)
+			}
+		})
+	}
+}
+
+func TestServer_GetComment(t *testing.T) {
+	svc := &fakeCommentService{}
+	client := newTestClient(t, svc)
+
+	got, err := client.GetComment(withAPIKey(testAPIKey), &commentsv1.GetCommentRequest{Id: testCommentID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Id != testCommentID || got.Content != "Hi" || got.Status != "ACTIVE" {
+		t.Errorf("unexpected comment %+v", got)
+	}
+	if svc.tenantID != "tenant-1" {
+		t.Errorf("expected tenant-1, got %q", svc.tenantID)
+	}
+}
+
+func TestServer_ErrorCodes(t *testing.T) {
+	client := newTestClient(t, &fakeCommentService{})
+	ctx := withAPIKey(testAPIKey)
+
+	_, err := client.GetComment(ctx, &commentsv1.GetCommentRequest{Id: "not-a-uuid"})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %s", got)
+	}
+
+	_, err = client.GetComment(ctx, &commentsv1.GetCommentRequest{Id: "7f1c2a4e-3b7d-4c1e-9a2b-8d0e5f6a7b8c"})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("expected NotFound, got %s", got)
+	}
+	if msg := status.Convert(err).Message(); msg != "comment not found" {
+		t.Errorf("expected AppError message, got %q", msg)
+	}
+}
+
+func TestServer_RecoversPanics(t *testing.T) {
+	client := newTestClient(t, &fakeCommentService{})
+	ctx := withAPIKey(testAPIKey)
+
+	_, err := client.DeleteComment(ctx, &commentsv1.DeleteCommentRequest{Id: "7f1c2a4e-3b7d-4c1e-9a2b-8d0e5f6a7b8c"})
+	if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "internal server error" {
+		t.Errorf("expected a sanitized Internal status, got %v", err)
+	}
+
+	// The server keeps serving
+	if _, err := client.DeleteComment(ctx, &commentsv1.DeleteCommentRequest{Id: testCommentID}); err != nil {
+		t.Errorf("unexpected error after a panic: %v", err)
+	}
+}
+
+func TestToStatus_FieldViolations(t *testing.T) {
+	err := toStatus(errors.Validation(errors.FieldError{Path: "content", Rule: "required", Message: "is required"}))
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %s", st.Code())
+	}
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one detail, got %v", details)
+	}
+	br, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "content" {
+		t.Errorf("expected content violation, got %v", details[0])
+	}
+}
+
+func TestToStatus_RetryInfo(t *testing.T) {
+	err := toStatus(errors.Unavailable("database unavailable", nil).WithRetryAfter(30 * time.Second))
+
+	st := status.Convert(err)
+	if st.Code() != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %s", st.Code())
+	}
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one detail, got %v", details)
+	}
+	info, ok := details[0].(*errdetails.RetryInfo)
+	if !ok || info.RetryDelay.AsDuration() != 30*time.Second {
+		t.Errorf("expected a 30s retry delay, got %v", details[0])
+	}
+}
+
+func TestServer_ListComments(t *testing.T) {
+	svc := &fakeCommentService{}
+	client := newTestClient(t, svc)
+
+	resp, err := client.ListComments(withAPIKey(testAPIKey), &commentsv1.ListCommentsRequest{
+		EntityType: "post",
+		EntityId:   "post_1",
+		Sort:       commentsv1.Sort_SORT_TOP,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.NextCursor != "next" {
+		t.Errorf("expected next cursor, got %q", resp.NextCursor)
+	}
+	if svc.listed.Sort != comment.SortTop || svc.listed.Limit != 10 {
+		t.Errorf("unexpected list input %+v", svc.listed)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not found", errors.NotFound("x"), codes.NotFound},
+		{"bad request", errors.BadRequest("x"), codes.InvalidArgument},
+		{"unauthorized", errors.Unauthorized("x"), codes.Unauthenticated},
+		{"conflict", errors.Conflict("x"), codes.AlreadyExists},
+		{"payload too large", errors.PayloadTooLarge("x"), codes.ResourceExhausted},
+		{"internal", errors.InternalServer("x", nil), codes.Internal},
+		{"plain error", context.Canceled, codes.Canceled},
+		{"status passthrough", status.Error(codes.Aborted, "x"), codes.Aborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(toStatus(tt.err)); got != tt.code {
+				t.Errorf("expected %s, got %s", tt.code, got)
+			}
+		})
+	}
+}
+
+type fakeLikeService struct {
+	reaction like.Reaction
+}
+
+func (s *fakeLikeService) Like(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (*applike.Result, error) {
+	s.reaction = reaction
+	return &applike.Result{CommentID: commentID, LikeCount: 1}, nil
+}
+
+func (s *fakeLikeService) Unlike(ctx context.Context, tenantID, commentID, userID string) (*applike.Result, error) {
+	return &applike.Result{CommentID: commentID}, nil
+}
+
+type fakeModerationService struct {
+	decided *appmoderation.ModerateInput
+}
+
+func (s *fakeModerationService) Queue(ctx context.Context, tenantID string, limit int) ([]*moderation.Item, error) {
+	return nil, nil
+}
+
+func (s *fakeModerationService) Flag(ctx context.Context, tenantID, id string) (*moderation.Item, error) {
+	return &moderation.Item{Comment: &comment.Comment{ID: id, Status: comment.StatusFlagged}, FlaggedCount: 1}, nil
+}
+
+func (s *fakeModerationService) Moderate(ctx context.Context, in appmoderation.ModerateInput) (*moderation.Item, error) {
+	s.decided = &in
+	return &moderation.Item{Comment: &comment.Comment{ID: in.ID, Status: in.Decision.Status()}, ModeratedBy: &in.ModeratorID}, nil
+}
+
+func TestServer_LikeComment(t *testing.T) {
+	likes := &fakeLikeService{}
+	client := commentsv1.NewLikeServiceClient(newTestConn(t, tenant.PlanFree, Dependencies{Likes: likes}))
+
+	got, err := client.LikeComment(withAPIKey(testAPIKey), &commentsv1.LikeCommentRequest{
+		CommentId: testCommentID,
+		UserId:    testCommentID,
+		Reaction:  commentsv1.Reaction_REACTION_WOW,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.LikeCount != 1 || likes.reaction != like.ReactionWow {
+		t.Errorf("expected a WOW counted once, got %v with %q", got, likes.reaction)
+	}
+}
+
+func TestServer_ModerateComment(t *testing.T) {
+	svc := &fakeModerationService{}
+	client := commentsv1.NewModerationServiceClient(newTestConn(t, tenant.PlanBusiness, Dependencies{Moderation: svc}))
+
+	got, err := client.ModerateComment(withAPIKey(testAPIKey), &commentsv1.ModerateCommentRequest{
+		Id:          testCommentID,
+		Decision:    commentsv1.Decision_DECISION_REJECT,
+		ModeratorId: testCommentID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Comment.Status != "SPAM" || svc.decided.Decision != moderation.DecisionReject {
+		t.Errorf("expected the comment rejected as spam, got %v", got)
+	}
+}
+
+func TestServer_FeatureGates(t *testing.T) {
+	// FREE includes likes but not advanced moderation
+	conn := newTestConn(t, tenant.PlanFree, Dependencies{Likes: &fakeLikeService{}, Moderation: &fakeModerationService{}})
+	ctx := withAPIKey(testAPIKey)
+
+	if _, err := commentsv1.NewLikeServiceClient(conn).UnlikeComment(ctx, &commentsv1.UnlikeCommentRequest{CommentId: testCommentID, UserId: testCommentID}); err != nil {
+		t.Errorf("expected likes to be enabled, got %v", err)
+	}
+	_, err := commentsv1.NewModerationServiceClient(conn).FlagComment(ctx, &commentsv1.FlagCommentRequest{Id: testCommentID})
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %s (%v)", got, err)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	limits := config.RateLimitConfig{Enabled: true, PerMinute: 1, PerHour: 10}
+	client := commentsv1.NewCommentServiceClient(newTestConn(t, tenant.PlanFree, Dependencies{
+		Comments:   &fakeCommentService{},
+		RateLimits: &memoryCounter{counts: map[string]int{}},
+		RateLimit:  func() config.RateLimitConfig { return limits },
+	}))
+	ctx := withAPIKey(testAPIKey)
+
+	if _, err := client.GetComment(ctx, &commentsv1.GetCommentRequest{Id: testCommentID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := client.GetComment(ctx, &commentsv1.GetCommentRequest{Id: testCommentID})
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %s (%v)", st.Code(), err)
+	}
+	if len(st.Details()) != 1 {
+		t.Errorf("expected a retry delay, got %v", st.Details())
+	}
+}
+
+type memoryCounter struct {
+	counts map[string]int
+}
+
+func (m *memoryCounter) Hit(ctx context.Context, key string, period time.Duration, now time.Time) (int, time.Time, error) {
+	start := now.Truncate(period)
+	k := key + period.String() + start.String()
+	m.counts[k]++
+	return m.counts[k], start.Add(period), nil
+}
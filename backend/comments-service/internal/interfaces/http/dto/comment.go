@@ -0,0 +1,204 @@
+// Package dto defines the JSON request and response bodies of the HTTP API.
+package dto
+
+import (
+	"time"
+
+	appcomment "github.com/ayushvyasgit/comments-service/internal/application/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+)
+
+// CreateCommentRequest is the body of POST /api/v1/comments
+type CreateCommentRequest struct {
+	EntityType    string  `json:"entity_type" binding:"required,max=100"`
+	EntityID      string  `json:"entity_id" binding:"required,max=255"`
+	ParentID      *string `json:"parent_id" binding:"omitempty,uuid"`
+	AuthorID      string  `json:"author_id" binding:"required,uuid"`
+	AuthorName    string  `json:"author_name" binding:"required,max=255"`
+	AuthorEmail   *string `json:"author_email" binding:"omitempty,email,max=255"`
+	Content       string  `json:"content" binding:"required"`
+	ContentFormat string  `json:"content_format" binding:"omitempty,oneof=plain markdown html"`
+}
+
+// UpdateCommentRequest is the body of PATCH /api/v1/comments/:id
+type UpdateCommentRequest struct {
+	Content  string  `json:"content" binding:"required"`
+	EditedBy string  `json:"edited_by" binding:"required,uuid"`
+	Reason   *string `json:"reason"`
+}
+
+// ListCommentsQuery is the query string of GET /api/v1/comments
+type ListCommentsQuery struct {
+	EntityType string  `form:"entity_type" binding:"required"`
+	EntityID   string  `form:"entity_id" binding:"required"`
+	ParentID   *string `form:"parent_id" binding:"omitempty,uuid"`
+	Sort       string  `form:"sort" binding:"omitempty,oneof=new old top"`
+	Cursor     string  `form:"cursor"`
+	Limit      int     `form:"limit"`
+}
+
+// CommentResponse is the public representation of a comment
+type CommentResponse struct {
+	ID            string     `json:"id"`
+	ParentID      *string    `json:"parent_id"`
+	Depth         int        `json:"depth"`
+	EntityType    string     `json:"entity_type"`
+	EntityID      string     `json:"entity_id"`
+	AuthorID      string     `json:"author_id"`
+	AuthorName    string     `json:"author_name"`
+	Content       string     `json:"content"`
+	ContentFormat string     `json:"content_format"`
+	Status        string     `json:"status"`
+	IsPinned      bool       `json:"is_pinned"`
+	IsEdited      bool       `json:"is_edited"`
+	LikeCount     int        `json:"like_count"`
+	ReplyCount    int        `json:"reply_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	EditedAt      *time.Time `json:"edited_at,omitempty"`
+}
+
+// Pagination carries the cursors of the pages around the one returned.
+// A cursor is omitted when there is nothing further in its direction.
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// ListCommentsResponse is the body returned by GET /api/v1/comments
+type ListCommentsResponse struct {
+	Data       []CommentResponse `json:"data"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// DeleteCommentResponse reports how many comments a delete removed
+type DeleteCommentResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// ToCommentResponse converts a domain comment to its public form
+func ToCommentResponse(c *comment.Comment) CommentResponse {
+	return CommentResponse{
+		ID:            c.ID,
+		ParentID:      c.ParentID,
+		Depth:         c.Depth,
+		EntityType:    c.EntityType,
+		EntityID:      c.EntityID,
+		AuthorID:      c.AuthorID,
+		AuthorName:    c.AuthorName,
+		Content:       c.Content,
+		ContentFormat: string(c.ContentFormat),
+		Status:        string(c.Status),
+		IsPinned:      c.IsPinned,
+		IsEdited:      c.IsEdited,
+		LikeCount:     c.LikeCount,
+		ReplyCount:    c.ReplyCount,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
+		EditedAt:      c.EditedAt,
+	}
+}
+
+// ToCommentResponses converts a slice of domain comments
+func ToCommentResponses(comments []*comment.Comment) []CommentResponse {
+	out := make([]CommentResponse, 0, len(comments))
+	for _, c := range comments {
+		out = append(out, ToCommentResponse(c))
+	}
+	return out
+}
+
+// CommentTreeQuery is the query string of the tree endpoints
+type CommentTreeQuery struct {
+	EntityType   string `form:"entity_type"`
+	EntityID     string `form:"entity_id"`
+	Continuation string `form:"continuation"`
+	Sort         string `form:"sort" binding:"omitempty,oneof=new old top"`
+	Limit        int    `form:"limit"`
+	Depth        int    `form:"depth"`
+	RepliesLimit int    `form:"replies_limit"`
+}
+
+// MoreResponse points at collapsed comments the client can load on demand.
+// Count is omitted when the number of hidden comments is not known.
+type MoreResponse struct {
+	Count        int    `json:"count,omitempty"`
+	Continuation string `json:"continuation"`
+}
+
+// CommentNodeResponse is a comment with its loaded replies
+type CommentNodeResponse struct {
+	CommentResponse
+	Replies []CommentNodeResponse `json:"replies"`
+	More    *MoreResponse         `json:"more,omitempty"`
+}
+
+// CommentTreeResponse is the body returned by the tree endpoints
+type CommentTreeResponse struct {
+	Data []CommentNodeResponse `json:"data"`
+	More *MoreResponse         `json:"more,omitempty"`
+}
+
+// ToCommentTreeResponse converts an application tree to its public form
+func ToCommentTreeResponse(t *appcomment.Tree) CommentTreeResponse {
+	return CommentTreeResponse{
+		Data: toCommentNodeResponses(t.Nodes),
+		More: toMoreResponse(t.More),
+	}
+}
+
+func toCommentNodeResponses(nodes []*appcomment.TreeNode) []CommentNodeResponse {
+	out := make([]CommentNodeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, CommentNodeResponse{
+			CommentResponse: ToCommentResponse(n.Comment),
+			Replies:         toCommentNodeResponses(n.Replies),
+			More:            toMoreResponse(n.More),
+		})
+	}
+	return out
+}
+
+func toMoreResponse(m *appcomment.More) *MoreResponse {
+	if m == nil {
+		return nil
+	}
+	return &MoreResponse{Count: m.Count, Continuation: m.Continuation}
+}
+
+// CommentStreamQuery is the query string of GET /api/v1/comments/stream
+type CommentStreamQuery struct {
+	EntityType  string `form:"entity_type" binding:"required"`
+	EntityID    string `form:"entity_id" binding:"required"`
+	LastEventID string `form:"last_event_id"`
+}
+
+// CommentEventResponse is the data of one Server-Sent Event
+type CommentEventResponse struct {
+	Type       string           `json:"type"`
+	CommentID  string           `json:"comment_id"`
+	Comment    *CommentResponse `json:"comment,omitempty"`
+	Deleted    int              `json:"deleted,omitempty"`
+	LikeCount  *int             `json:"like_count,omitempty"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
+
+// ToCommentEventResponse converts a domain event to its public form
+func ToCommentEventResponse(e comment.Event) CommentEventResponse {
+	out := CommentEventResponse{
+		Type:       string(e.Type),
+		CommentID:  e.CommentID,
+		Deleted:    e.Deleted,
+		OccurredAt: e.OccurredAt,
+	}
+	if e.Comment != nil {
+		c := ToCommentResponse(e.Comment)
+		out.Comment = &c
+	}
+	if e.Type == comment.EventReactions {
+		likes := e.LikeCount
+		out.LikeCount = &likes
+	}
+	return out
+}
@@ -0,0 +1,20 @@
+package dto
+
+import applike "github.com/ayushvyasgit/comments-service/internal/application/like"
+
+// LikeCommentRequest is the body of POST /api/v1/comments/:id/likes
+type LikeCommentRequest struct {
+	UserID   string `json:"user_id" binding:"required,uuid"`
+	Reaction string `json:"reaction" binding:"omitempty,oneof=LIKE LOVE LAUGH WOW SAD ANGRY"`
+}
+
+// LikeCountResponse is the like count of a comment after a like or unlike
+type LikeCountResponse struct {
+	CommentID string `json:"comment_id"`
+	LikeCount int    `json:"like_count"`
+}
+
+// ToLikeCountResponse converts the result of a like or unlike
+func ToLikeCountResponse(r *applike.Result) LikeCountResponse {
+	return LikeCountResponse{CommentID: r.CommentID, LikeCount: r.LikeCount}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+)
+
+// TenantSettingsResponse is the body of GET /api/v1/settings: the stored
+// values merged over the plan defaults
+type TenantSettingsResponse struct {
+	Plan     string             `json:"plan"`
+	Features settings.Features  `json:"features"`
+	Settings *settings.Settings `json:"settings"`
+}
+
+// UpdateTenantSettingsRequest is the body of PATCH /api/v1/settings. Each
+// object holds only the keys to change.
+type UpdateTenantSettingsRequest struct {
+	Features json.RawMessage `json:"features"`
+	Settings json.RawMessage `json:"settings"`
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/validation"
+)
+
+// bindJSON decodes and validates the request body into obj, responding
+// with every invalid field when it fails
+func bindJSON(c *gin.Context, obj any) bool {
+	validation.Register()
+	if err := c.ShouldBindJSON(obj); err != nil {
+		response.Error(c, validation.FromBindError(err))
+		return false
+	}
+	return true
+}
+
+// bindQuery is bindJSON for the query string
+func bindQuery(c *gin.Context, obj any) bool {
+	validation.Register()
+	if err := c.ShouldBindQuery(obj); err != nil {
+		response.Error(c, validation.FromBindError(err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,237 @@
+// Package handlers implements the HTTP handlers of the API.
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appcomment "github.com/ayushvyasgit/comments-service/internal/application/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/dto"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// CommentService is the application service used by CommentHandler
+type CommentService interface {
+	Create(ctx context.Context, in appcomment.CreateInput) (*comment.Comment, error)
+	Get(ctx context.Context, tenantID, id string) (*comment.Comment, error)
+	Update(ctx context.Context, in appcomment.UpdateInput) (*comment.Comment, error)
+	Delete(ctx context.Context, tenantID, id string) (int, error)
+	List(ctx context.Context, in appcomment.ListInput) (*appcomment.ListResult, error)
+	Tree(ctx context.Context, in appcomment.TreeInput) (*appcomment.Tree, error)
+}
+
+// CommentHandler serves the /api/v1/comments endpoints
+type CommentHandler struct {
+	service CommentService
+}
+
+// NewCommentHandler creates a comment handler
+func NewCommentHandler(service CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+// Create handles POST /api/v1/comments
+func (h *CommentHandler) Create(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	created, err := h.service.Create(c.Request.Context(), appcomment.CreateInput{
+		TenantID:      t.ID,
+		EntityType:    req.EntityType,
+		EntityID:      req.EntityID,
+		ParentID:      req.ParentID,
+		AuthorID:      req.AuthorID,
+		AuthorName:    req.AuthorName,
+		AuthorEmail:   req.AuthorEmail,
+		Content:       req.Content,
+		ContentFormat: comment.Format(req.ContentFormat),
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToCommentResponse(created))
+}
+
+// Get handles GET /api/v1/comments/:id
+func (h *CommentHandler) Get(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+
+	found, err := h.service.Get(c.Request.Context(), t.ID, id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCommentResponse(found))
+}
+
+// Update handles PATCH /api/v1/comments/:id
+func (h *CommentHandler) Update(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	updated, err := h.service.Update(c.Request.Context(), appcomment.UpdateInput{
+		TenantID: t.ID,
+		ID:       id,
+		Content:  req.Content,
+		EditedBy: req.EditedBy,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCommentResponse(updated))
+}
+
+// Delete handles DELETE /api/v1/comments/:id
+func (h *CommentHandler) Delete(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.service.Delete(c.Request.Context(), t.ID, id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DeleteCommentResponse{Deleted: deleted})
+}
+
+// List handles GET /api/v1/comments
+func (h *CommentHandler) List(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+
+	var q dto.ListCommentsQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), appcomment.ListInput{
+		TenantID:   t.ID,
+		EntityType: q.EntityType,
+		EntityID:   q.EntityID,
+		ParentID:   q.ParentID,
+		Sort:       comment.Sort(q.Sort),
+		Cursor:     q.Cursor,
+		Limit:      q.Limit,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListCommentsResponse{
+		Data: dto.ToCommentResponses(result.Comments),
+		Pagination: dto.Pagination{
+			Limit:      result.Limit,
+			NextCursor: result.Next,
+			PrevCursor: result.Prev,
+		},
+	})
+}
+
+// Tree handles GET /api/v1/comments/tree, returning the nested thread of an
+// entity or the level addressed by a continuation token
+func (h *CommentHandler) Tree(c *gin.Context) {
+	h.tree(c, nil)
+}
+
+// Subtree handles GET /api/v1/comments/:id/tree
+func (h *CommentHandler) Subtree(c *gin.Context) {
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+	h.tree(c, &id)
+}
+
+func (h *CommentHandler) tree(c *gin.Context, rootID *string) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+
+	var q dto.CommentTreeQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+
+	tree, err := h.service.Tree(c.Request.Context(), appcomment.TreeInput{
+		TenantID:     t.ID,
+		EntityType:   q.EntityType,
+		EntityID:     q.EntityID,
+		RootID:       rootID,
+		Continuation: q.Continuation,
+		Sort:         comment.Sort(q.Sort),
+		Limit:        q.Limit,
+		Depth:        q.Depth,
+		RepliesLimit: q.RepliesLimit,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCommentTreeResponse(tree))
+}
+
+func tenantFrom(c *gin.Context) (*tenant.Tenant, bool) {
+	t, ok := tenant.FromContext(c.Request.Context())
+	if !ok {
+		response.Error(c, errors.Unauthorized("missing tenant"))
+	}
+	return t, ok
+}
+
+func commentID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		response.Error(c, errors.BadRequest("invalid comment id").WithMessageID("comment.invalid_id", nil))
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	appcomment "github.com/ayushvyasgit/comments-service/internal/application/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+const testCommentID = "6f1c2a4e-3b7d-4c1e-9a2b-8d0e5f6a7b8c"
+
+type fakeCommentService struct {
+	created *appcomment.CreateInput
+	tree    *appcomment.TreeInput
+	rootID  string
+}
+
+func (s *fakeCommentService) Create(ctx context.Context, in appcomment.CreateInput) (*comment.Comment, error) {
+	s.created = &in
+	return &comment.Comment{ID: testCommentID, TenantID: in.TenantID, Content: in.Content, Status: comment.StatusActive}, nil
+}
+
+func (s *fakeCommentService) Get(ctx context.Context, tenantID, id string) (*comment.Comment, error) {
+	return nil, errors.NotFound("comment not found")
+}
+
+func (s *fakeCommentService) Update(ctx context.Context, in appcomment.UpdateInput) (*comment.Comment, error) {
+	return &comment.Comment{ID: in.ID, Content: in.Content, IsEdited: true}, nil
+}
+
+func (s *fakeCommentService) Delete(ctx context.Context, tenantID, id string) (int, error) {
+	return 3, nil
+}
+
+func (s *fakeCommentService) List(ctx context.Context, in appcomment.ListInput) (*appcomment.ListResult, error) {
+	return &appcomment.ListResult{Limit: 20, Next: "next"}, nil
+}
+
+func (s *fakeCommentService) Tree(ctx context.Context, in appcomment.TreeInput) (*appcomment.Tree, error) {
+	root := &appcomment.TreeNode{
+		Comment: &comment.Comment{ID: testCommentID, ReplyCount: 4},
+		Replies: []*appcomment.TreeNode{{Comment: &comment.Comment{ID: "reply", ParentID: &s.rootID}}},
+		More:    &appcomment.More{Count: 3, Continuation: "token"},
+	}
+	s.tree = &in
+	return &appcomment.Tree{Nodes: []*appcomment.TreeNode{root}}, nil
+}
+
+func newTestRouter(svc CommentService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		t := &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive}
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), t))
+	})
+	h := NewCommentHandler(svc)
+	r.POST("/comments", h.Create)
+	r.GET("/comments", h.List)
+	r.GET("/comments/:id", h.Get)
+	r.PATCH("/comments/:id", h.Update)
+	r.DELETE("/comments/:id", h.Delete)
+	r.GET("/comments/tree", h.Tree)
+	r.GET("/comments/:id/tree", h.Subtree)
+	return r
+}
+
+func TestCommentHandler_Create(t *testing.T) {
+	svc := &fakeCommentService{}
+	r := newTestRouter(svc)
+
+	body := `{"entity_type":"post","entity_id":"post_1","author_id":"` + testCommentID + `","author_name":"Ada","content":"Hi"}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/comments", strings.NewReader(body)))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if svc.created == nil || svc.created.TenantID != "tenant-1" {
+		t.Errorf("expected create to be scoped to tenant-1, got %+v", svc.created)
+	}
+}
+
+func TestCommentHandler_Errors(t *testing.T) {
+	r := newTestRouter(&fakeCommentService{})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"invalid body", http.MethodPost, "/comments", `{"entity_type":"post"}`, http.StatusUnprocessableEntity, errors.ErrCodeValidation},
+		{"malformed body", http.MethodPost, "/comments", `{"entity_type":`, http.StatusBadRequest, errors.ErrCodeBadRequest},
+		{"invalid id", http.MethodGet, "/comments/not-a-uuid", "", http.StatusBadRequest, errors.ErrCodeBadRequest},
+		{"not found", http.MethodGet, "/comments/" + testCommentID, "", http.StatusNotFound, errors.ErrCodeNotFound},
+		{"missing entity", http.MethodGet, "/comments", "", http.StatusUnprocessableEntity, errors.ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != response.ProblemContentType {
+				t.Errorf("expected %s, got %s", response.ProblemContentType, ct)
+			}
+			var body response.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if body.Code != tt.code || body.Type != response.TypeURI(tt.code) || body.Status != tt.status {
+				t.Errorf("expected %s problem, got %+v", tt.code, body)
+			}
+		})
+	}
+}
+
+func TestCommentHandler_ValidationListsEveryField(t *testing.T) {
+	r := newTestRouter(&fakeCommentService{})
+
+	body := `{"entity_type":"post","author_id":"nope","author_name":"Ann","author_email":"x","content":"hi","content_format":"rtf","parent_id":7}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/comments", strings.NewReader(body)))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", w.Code)
+	}
+
+	// A value of the wrong type stops decoding before the rules run
+	var p response.Problem
+	json.Unmarshal(w.Body.Bytes(), &p)
+	if len(p.Errors) != 1 || p.Errors[0].Path != "parent_id" || p.Errors[0].Rule != "type" {
+		t.Errorf("expected parent_id type violation, got %+v", p.Errors)
+	}
+
+	body = `{"entity_type":"post","author_id":"nope","author_name":"Ann","author_email":"x","content":"hi","content_format":"rtf"}`
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/comments", strings.NewReader(body)))
+	p = response.Problem{}
+	json.Unmarshal(w.Body.Bytes(), &p)
+
+	got := make(map[string]string)
+	for _, fe := range p.Errors {
+		got[fe.Path] = fe.Rule
+	}
+	want := map[string]string{"entity_id": "required", "author_id": "uuid", "author_email": "email", "content_format": "oneof"}
+	if len(got) != len(want) {
+		t.Errorf("expected %d violations, got %+v", len(want), p.Errors)
+	}
+	for path, rule := range want {
+		if got[path] != rule {
+			t.Errorf("expected %s to fail %s, got %q", path, rule, got[path])
+		}
+	}
+}
+
+func TestCommentHandler_Delete(t *testing.T) {
+	r := newTestRouter(&fakeCommentService{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/comments/"+testCommentID, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"deleted":3`) {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCommentHandler_Tree(t *testing.T) {
+	svc := &fakeCommentService{rootID: testCommentID}
+	r := newTestRouter(svc)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/"+testCommentID+"/tree?depth=2", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if svc.tree.RootID == nil || *svc.tree.RootID != testCommentID || svc.tree.Depth != 2 {
+		t.Errorf("unexpected tree input: %+v", svc.tree)
+	}
+
+	var body struct {
+		Data []struct {
+			ID      string `json:"id"`
+			Replies []struct {
+				ID string `json:"id"`
+			} `json:"replies"`
+			More *struct {
+				Count        int    `json:"count"`
+				Continuation string `json:"continuation"`
+			} `json:"more"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Data) != 1 || len(body.Data[0].Replies) != 1 {
+		t.Fatalf("unexpected tree: %s", w.Body.String())
+	}
+	if body.Data[0].More == nil || body.Data[0].More.Continuation != "token" {
+		t.Errorf("expected continuation on root, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/tree?continuation=abc", nil))
+	if w.Code != http.StatusOK || svc.tree.Continuation != "abc" || svc.tree.RootID != nil {
+		t.Errorf("expected continuation request, got status %d input %+v", w.Code, svc.tree)
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/health"
+)
+
+// HealthChecker produces the readiness report
+type HealthChecker interface {
+	Check(ctx context.Context) health.Report
+}
+
+// HealthHandler serves the liveness and readiness endpoints
+type HealthHandler struct {
+	checker HealthChecker
+}
+
+// NewHealthHandler creates a health handler
+func NewHealthHandler(checker HealthChecker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Live handles GET /health and GET /health/live. It only reports that the
+// process is serving requests and never touches dependencies.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"service": "comments-service",
+	})
+}
+
+// Ready handles GET /health/ready, answering 503 with per-component detail
+// when any dependency is unhealthy
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Check(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// Root handles GET /
+func Root(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comments Service API",
+		"version": "1.0.0",
+	})
+}
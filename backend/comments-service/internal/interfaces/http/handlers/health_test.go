@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/infrastructure/health"
+)
+
+type fakeChecker struct {
+	report health.Report
+}
+
+func (f fakeChecker) Check(ctx context.Context) health.Report {
+	return f.report
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		status health.Status
+		code   int
+	}{
+		{"ok", health.StatusOK, http.StatusOK},
+		{"degraded", health.StatusDegraded, http.StatusServiceUnavailable},
+		{"unavailable", health.StatusUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(fakeChecker{report: health.Report{
+				Status: tt.status,
+				Components: map[string]health.ComponentReport{
+					"postgres": {Status: tt.status, Critical: true},
+				},
+			}})
+			r := gin.New()
+			r.GET("/health/ready", h.Ready)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			if w.Code != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), `"postgres"`) {
+				t.Errorf("expected component detail, got %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	applike "github.com/ayushvyasgit/comments-service/internal/application/like"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/dto"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// LikeService is the application service used by LikeHandler
+type LikeService interface {
+	Like(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (*applike.Result, error)
+	Unlike(ctx context.Context, tenantID, commentID, userID string) (*applike.Result, error)
+}
+
+// LikeHandler serves the /api/v1/comments/:id/likes endpoints
+type LikeHandler struct {
+	service LikeService
+}
+
+// NewLikeHandler creates a like handler
+func NewLikeHandler(service LikeService) *LikeHandler {
+	return &LikeHandler{service: service}
+}
+
+// Like handles POST /api/v1/comments/:id/likes
+func (h *LikeHandler) Like(c *gin.Context) {
+	tenantID, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req dto.LikeCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	res, err := h.service.Like(c.Request.Context(), tenantID, id, req.UserID, like.Reaction(req.Reaction))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToLikeCountResponse(res))
+}
+
+// Unlike handles DELETE /api/v1/comments/:id/likes/:user_id
+func (h *LikeHandler) Unlike(c *gin.Context) {
+	tenantID, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		response.Error(c, errors.Validation(errors.FieldError{Path: "user_id", Rule: "uuid", Message: "must be a UUID"}))
+		return
+	}
+
+	res, err := h.service.Unlike(c.Request.Context(), tenantID, id, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToLikeCountResponse(res))
+}
+
+// target returns the tenant and comment of a like request, rejecting it
+// when the tenant's plan or settings turn likes off
+func (h *LikeHandler) target(c *gin.Context) (tenantID, id string, ok bool) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return "", "", false
+	}
+	if !settings.For(c.Request.Context()).Features.LikesEnabled {
+		response.Error(c, errors.Forbidden("likes are not enabled for this tenant").WithMessageID("likes.disabled", nil))
+		return "", "", false
+	}
+	id, ok = commentID(c)
+	if !ok {
+		return "", "", false
+	}
+	return t.ID, id, true
+}
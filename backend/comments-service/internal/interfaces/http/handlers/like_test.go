@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	applike "github.com/ayushvyasgit/comments-service/internal/application/like"
+	"github.com/ayushvyasgit/comments-service/internal/domain/like"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+const testUserID = "0c9d8e7f-6a5b-4c3d-8e2f-1a0b9c8d7e6f"
+
+type fakeLikeService struct {
+	reaction like.Reaction
+	unliked  string
+}
+
+func (s *fakeLikeService) Like(ctx context.Context, tenantID, commentID, userID string, reaction like.Reaction) (*applike.Result, error) {
+	s.reaction = reaction
+	return &applike.Result{CommentID: commentID, LikeCount: 5}, nil
+}
+
+func (s *fakeLikeService) Unlike(ctx context.Context, tenantID, commentID, userID string) (*applike.Result, error) {
+	s.unliked = userID
+	return &applike.Result{CommentID: commentID, LikeCount: 4}, nil
+}
+
+func newLikeRouter(svc LikeService, features string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		t := &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive, Features: json.RawMessage(features)}
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), t))
+	})
+	h := NewLikeHandler(svc)
+	r.POST("/comments/:id/likes", h.Like)
+	r.DELETE("/comments/:id/likes/:user_id", h.Unlike)
+	return r
+}
+
+func TestLikeHandler_Like(t *testing.T) {
+	svc := &fakeLikeService{}
+	r := newLikeRouter(svc, `{"likes_enabled": true}`)
+
+	body := `{"user_id": "` + testUserID + `", "reaction": "LOVE"}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/comments/"+testCommentID+"/likes", strings.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if svc.reaction != like.ReactionLove {
+		t.Errorf("expected reaction LOVE, got %q", svc.reaction)
+	}
+	if !strings.Contains(w.Body.String(), `"like_count":5`) {
+		t.Errorf("expected the like count in the body, got %s", w.Body.String())
+	}
+}
+
+func TestLikeHandler_Unlike(t *testing.T) {
+	svc := &fakeLikeService{}
+	r := newLikeRouter(svc, `{"likes_enabled": true}`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/comments/"+testCommentID+"/likes/"+testUserID, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if svc.unliked != testUserID {
+		t.Errorf("expected user %s to unlike, got %q", testUserID, svc.unliked)
+	}
+}
+
+func TestLikeHandler_Rejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		features string
+		method   string
+		path     string
+		body     string
+		status   int
+	}{
+		{"likes disabled", `{"likes_enabled": false}`, http.MethodPost, "/comments/" + testCommentID + "/likes", `{"user_id": "` + testUserID + `"}`, http.StatusForbidden},
+		{"invalid comment id", `{}`, http.MethodPost, "/comments/nope/likes", `{"user_id": "` + testUserID + `"}`, http.StatusBadRequest},
+		{"missing user", `{}`, http.MethodPost, "/comments/" + testCommentID + "/likes", `{}`, http.StatusUnprocessableEntity},
+		{"unknown reaction", `{}`, http.MethodPost, "/comments/" + testCommentID + "/likes", `{"user_id": "` + testUserID + `", "reaction": "MEH"}`, http.StatusUnprocessableEntity},
+		{"invalid user id", `{}`, http.MethodDelete, "/comments/" + testCommentID + "/likes/alice", "", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newLikeRouter(&fakeLikeService{}, tt.features)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// OpenAPIHandler serves the OpenAPI document in YAML and JSON
+type OpenAPIHandler struct {
+	yaml    []byte
+	json    []byte
+	jsonErr error
+}
+
+// NewOpenAPIHandler creates a handler for the given YAML document. The JSON
+// form is rendered once up front.
+func NewOpenAPIHandler(spec []byte) *OpenAPIHandler {
+	h := &OpenAPIHandler{yaml: spec}
+	var doc any
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		h.jsonErr = err
+		return h
+	}
+	h.json, h.jsonErr = json.Marshal(doc)
+	return h
+}
+
+// YAML handles GET /openapi.yaml
+func (h *OpenAPIHandler) YAML(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", h.yaml)
+}
+
+// JSON handles GET /openapi.json
+func (h *OpenAPIHandler) JSON(c *gin.Context) {
+	if h.jsonErr != nil {
+		response.Error(c, errors.InternalServer("failed to render OpenAPI document", h.jsonErr))
+		return
+	}
+	c.Data(http.StatusOK, "application/json", h.json)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/dto"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// SettingsService is the application service used by SettingsHandler
+type SettingsService interface {
+	Update(ctx context.Context, tenantID string, plan tenant.Plan, features, values json.RawMessage) error
+}
+
+// SettingsHandler serves the /api/v1/settings endpoints
+type SettingsHandler struct {
+	service SettingsService
+}
+
+// NewSettingsHandler creates a settings handler
+func NewSettingsHandler(service SettingsService) *SettingsHandler {
+	return &SettingsHandler{service: service}
+}
+
+// Get handles GET /api/v1/settings
+func (h *SettingsHandler) Get(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	s := settings.For(c.Request.Context())
+	c.JSON(http.StatusOK, dto.TenantSettingsResponse{
+		Plan:     string(t.Plan),
+		Features: s.Features,
+		Settings: s,
+	})
+}
+
+// Update handles PATCH /api/v1/settings. It needs an API key with the admin
+// scope.
+func (h *SettingsHandler) Update(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	if !t.HasScope(tenant.ScopeAdmin) {
+		response.Error(c, errors.Forbidden("changing settings requires an API key with the admin scope").WithMessageID("auth.admin_required", nil))
+		return
+	}
+
+	var req dto.UpdateTenantSettingsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if err := h.service.Update(c.Request.Context(), t.ID, t.Plan, req.Features, req.Settings); err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
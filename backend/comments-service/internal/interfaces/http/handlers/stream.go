@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/dto"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// LastEventIDHeader is sent by EventSource clients when they reconnect
+const LastEventIDHeader = "Last-Event-ID"
+
+// CommentEventStream subscribes to the live events of an entity
+type CommentEventStream interface {
+	Subscribe(ctx context.Context, tenantID, entityType, entityID, lastEventID string) (<-chan comment.Event, error)
+}
+
+// StreamHandler serves live comment events as Server-Sent Events
+type StreamHandler struct {
+	events    CommentEventStream
+	heartbeat time.Duration
+}
+
+// NewStreamHandler creates a stream handler that pings idle connections
+// every heartbeat so proxies keep them open
+func NewStreamHandler(events CommentEventStream, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{events: events, heartbeat: heartbeat}
+}
+
+// Stream handles GET /api/v1/comments/stream
+func (h *StreamHandler) Stream(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	if !settings.For(c.Request.Context()).Features.RealTimeEnabled {
+		response.Error(c, errors.Forbidden("real-time updates are not enabled for this tenant").WithMessageID("realtime.disabled", nil))
+		return
+	}
+
+	var q dto.CommentStreamQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+	// Browsers only send the header on automatic reconnects; the query
+	// parameter covers clients resuming a fresh EventSource
+	lastEventID := c.GetHeader(LastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = q.LastEventID
+	}
+
+	ctx := c.Request.Context()
+	events, err := h.events.Subscribe(ctx, t.ID, q.EntityType, q.EntityID, lastEventID)
+	if err != nil {
+		response.Error(c, errors.InternalServer("failed to subscribe to comment events", err))
+		return
+	}
+
+	// The stream is expected to outlive the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(c.Writer, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w gin.ResponseWriter, e comment.Event) error {
+	data, err := json.Marshal(dto.ToCommentEventResponse(e))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+type fakeEventStream struct {
+	lastEventID string
+	events      []comment.Event
+}
+
+func (s *fakeEventStream) Subscribe(ctx context.Context, tenantID, entityType, entityID, lastEventID string) (<-chan comment.Event, error) {
+	s.lastEventID = lastEventID
+	ch := make(chan comment.Event, len(s.events))
+	for _, e := range s.events {
+		ch <- e
+	}
+	close(ch)
+	return ch, nil
+}
+
+func newStreamRouter(stream CommentEventStream, features string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		t := &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive, Features: json.RawMessage(features)}
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), t))
+	})
+	r.GET("/comments/stream", NewStreamHandler(stream, time.Minute).Stream)
+	return r
+}
+
+func TestStreamHandler_FeatureDisabled(t *testing.T) {
+	r := newStreamRouter(&fakeEventStream{}, `{"real_time_enabled": false}`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/stream?entity_type=post&entity_id=post_1", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestStreamHandler_Stream(t *testing.T) {
+	stream := &fakeEventStream{events: []comment.Event{
+		{ID: "2-0", Type: comment.EventCreated, CommentID: testCommentID, Comment: &comment.Comment{ID: testCommentID, Content: "Hi"}},
+		{ID: "3-0", Type: comment.EventReactions, CommentID: testCommentID},
+	}}
+	r := newStreamRouter(stream, `{"real_time_enabled": true}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/comments/stream?entity_type=post&entity_id=post_1&last_event_id=0-1", nil)
+	req.Header.Set(LastEventIDHeader, "1-0")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+	if stream.lastEventID != "1-0" {
+		t.Errorf("expected the header to win over the query, got %q", stream.lastEventID)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"id: 2-0\nevent: comment.created\ndata: {",
+		`"content":"Hi"`,
+		"id: 3-0\nevent: comment.reactions\n",
+		`"like_count":0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got %s", want, body)
+		}
+	}
+}
+
+func TestStreamHandler_MissingEntity(t *testing.T) {
+	r := newStreamRouter(&fakeEventStream{}, `{"real_time_enabled": true}`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/stream?entity_type=post", nil))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", w.Code)
+	}
+}
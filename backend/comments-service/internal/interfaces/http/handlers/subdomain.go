@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// SubdomainService is the application service used by SubdomainHandler
+type SubdomainService interface {
+	Check(ctx context.Context, name string) (*apptenant.SubdomainAvailability, error)
+}
+
+// SubdomainHandler serves the /api/v1/subdomains endpoints used when
+// provisioning tenants
+type SubdomainHandler struct {
+	service SubdomainService
+}
+
+// NewSubdomainHandler creates a subdomain handler
+func NewSubdomainHandler(service SubdomainService) *SubdomainHandler {
+	return &SubdomainHandler{service: service}
+}
+
+// Check handles GET /api/v1/subdomains/:name. It needs an API key with the
+// admin scope, so tenants cannot be enumerated with any key.
+func (h *SubdomainHandler) Check(c *gin.Context) {
+	t, ok := tenantFrom(c)
+	if !ok {
+		return
+	}
+	if !t.HasScope(tenant.ScopeAdmin) {
+		response.Error(c, errors.Forbidden("checking subdomains requires an API key with the admin scope").WithMessageID("auth.admin_required_subdomains", nil))
+		return
+	}
+
+	result, err := h.service.Check(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/subdomain"
+)
+
+type fakeSubdomainService struct {
+	checked string
+}
+
+func (s *fakeSubdomainService) Check(ctx context.Context, name string) (*apptenant.SubdomainAvailability, error) {
+	s.checked = name
+	return &apptenant.SubdomainAvailability{Subdomain: name, Display: name, Reason: subdomain.ReasonTaken, Suggestions: []string{name + "-hq"}}, nil
+}
+
+func TestSubdomainHandler_Check(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		scopes []string
+		code   int
+	}{
+		{"admin", []string{tenant.ScopeAdmin}, http.StatusOK},
+		{"without admin scope", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeSubdomainService{}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				tn := &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive, Scopes: tt.scopes}
+				c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), tn))
+			})
+			r.GET("/subdomains/:name", NewSubdomainHandler(svc).Check)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/subdomains/acme", nil))
+
+			if w.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, w.Code, w.Body)
+			}
+			if tt.code == http.StatusOK && (svc.checked != "acme" || !strings.Contains(w.Body.String(), `"suggestions":["acme-hq"]`)) {
+				t.Errorf("expected the availability of acme, got %s", w.Body)
+			}
+			if tt.code != http.StatusOK && svc.checked != "" {
+				t.Errorf("expected no check without the admin scope")
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// BodyLimit rejects requests whose declared Content-Length exceeds limit
+// and caps the body reader for requests that do not declare one. Reading past
+// the cap fails with *http.MaxBytesError, which validation.FromBindError
+// turns into the same 413.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > limit {
+			response.Error(c, errors.PayloadTooLarge("request body too large").WithMessageID("error.body_too_large", nil))
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+)
+
+// corsMaxAge is how long browsers may cache a preflight answer, in seconds
+const corsMaxAge = "600"
+
+// corsExposed are the headers of the API that scripts on other origins may
+// read
+var corsExposed = strings.Join([]string{
+	RequestIDHeader,
+	"Content-Language",
+	IdempotentReplayedHeader,
+	response.HeaderRetryAfter,
+	response.HeaderRateLimitLimit,
+	response.HeaderRateLimitRemaining,
+	response.HeaderRateLimitReset,
+}, ", ")
+
+// CORS lets browsers on the allowed origins call the API and answers their
+// preflight requests. settings is read per request so reloaded origins apply
+// immediately; "*" allows every origin.
+func CORS(settings func() config.CORSConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		cfg := settings()
+		h := c.Writer.Header()
+		h.Add("Vary", "Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		if !slices.Contains(cfg.AllowedOrigins, origin) && !slices.Contains(cfg.AllowedOrigins, "*") {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		h.Set("Access-Control-Allow-Origin", origin)
+		if preflight {
+			h.Set("Access-Control-Allow-Methods", strings.Join(cfg.AllowedMethods, ", "))
+			h.Set("Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
+			h.Set("Access-Control-Max-Age", corsMaxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		h.Set("Access-Control-Expose-Headers", corsExposed)
+		c.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	settings := config.CORSConfig{
+		AllowedOrigins: []string{"https://app.example"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+	}
+	r := gin.New()
+	r.Use(CORS(func() config.CORSConfig { return settings }))
+	r.GET("/comments", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		preflight   bool
+		code        int
+		allowOrigin string
+	}{
+		{"same origin", http.MethodGet, "", false, http.StatusOK, ""},
+		{"allowed", http.MethodGet, "https://app.example", false, http.StatusOK, "https://app.example"},
+		{"other origin", http.MethodGet, "https://evil.example", false, http.StatusOK, ""},
+		{"preflight", http.MethodOptions, "https://app.example", true, http.StatusNoContent, "https://app.example"},
+		{"preflight other origin", http.MethodOptions, "https://evil.example", true, http.StatusForbidden, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/comments", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("expected allowed origin %q, got %q", tt.allowOrigin, got)
+			}
+			exposed := w.Header().Get("Access-Control-Expose-Headers")
+			if want := tt.allowOrigin != "" && !tt.preflight; want != (strings.Contains(exposed, "X-Request-ID") && strings.Contains(exposed, "X-RateLimit-Remaining")) {
+				t.Errorf("expected exposed headers %v, got %q", want, exposed)
+			}
+		})
+	}
+
+	// Reloaded origins apply to the next request
+	settings.AllowedOrigins = []string{"*"}
+	req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://evil.example" {
+		t.Errorf("expected wildcard to allow any origin, got %v", w.Header())
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// RequestIDHeader carries the request ID, taken from the client or proxy
+// when present and generated otherwise
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs accepted from clients
+const maxRequestIDLength = 128
+
+// RequestID assigns every request an ID, echoed in the response header and
+// in error responses so clients can quote it
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set(response.RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID accepts short printable ASCII IDs, so a client cannot
+// inject anything into logs or headers
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Errors renders errors that handlers added with c.Error but did not send
+// and turns panics into a sanitized 500. Every wrapped error is logged with
+// the request ID and the stack where it was created; clients only see the
+// problem details.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			slog.Error("panic while serving request",
+				"request_id", c.GetString(response.RequestIDKey),
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"panic", rec,
+				"stack", string(debug.Stack()),
+			)
+			c.Abort()
+			response.WriteProblem(c, response.NewProblem(c, errors.InternalServer("internal server error", nil)))
+		}()
+
+		c.Next()
+
+		if last := c.Errors.Last(); last != nil && !c.Writer.Written() {
+			var appErr *errors.AppError
+			if !stderrors.As(last.Err, &appErr) {
+				appErr = errors.InternalServer("internal server error", last.Err)
+			}
+			response.SetRetryHeaders(c.Writer.Header(), appErr)
+			response.WriteProblem(c, response.NewProblem(c, appErr))
+		}
+		for _, ginErr := range c.Errors {
+			logError(c, ginErr.Err)
+		}
+	}
+}
+
+func logError(c *gin.Context, err error) {
+	attrs := []any{
+		"request_id", c.GetString(response.RequestIDKey),
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"status", c.Writer.Status(),
+		"error", err.Error(),
+	}
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		attrs = append(attrs, "code", appErr.Code)
+		if stack := appErr.Stack(); stack != "" {
+			attrs = append(attrs, "stack", stack)
+		}
+	}
+	slog.Error("request failed", attrs...)
+}
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+func TestErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID(), Errors())
+	r.GET("/rendered", func(c *gin.Context) {
+		appErr := errors.NotFound("comment not found")
+		appErr.Errors = []errors.FieldError{{Path: "id", Rule: "exists", Message: "is unknown"}}
+		response.Error(c, appErr)
+	})
+	r.GET("/recorded", func(c *gin.Context) {
+		_ = c.Error(errors.Conflict("already exists"))
+	})
+	r.GET("/wrapped", func(c *gin.Context) {
+		response.Error(c, errors.InternalServer("failed to load comment", stderrors.New("dial tcp 10.0.0.5:5432: secret detail")))
+	})
+	r.GET("/unknown", func(c *gin.Context) {
+		_ = c.Error(stderrors.New("pq: secret detail"))
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("secret detail")
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		code   string
+		fields int
+	}{
+		{"/rendered", http.StatusNotFound, errors.ErrCodeNotFound, 1},
+		{"/recorded", http.StatusConflict, errors.ErrCodeConflict, 0},
+		{"/wrapped", http.StatusInternalServerError, errors.ErrCodeInternalServer, 0},
+		{"/unknown", http.StatusInternalServerError, errors.ErrCodeInternalServer, 0},
+		{"/panic", http.StatusInternalServerError, errors.ErrCodeInternalServer, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(RequestIDHeader, "req-123")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != response.ProblemContentType {
+				t.Errorf("expected %s, got %q", response.ProblemContentType, ct)
+			}
+			if strings.Contains(w.Body.String(), "secret") {
+				t.Errorf("expected internal details to stay out of the response, got %s", w.Body.String())
+			}
+
+			var p response.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if p.Code != tt.code || p.Type != response.TypeURI(tt.code) || p.Status != tt.status {
+				t.Errorf("expected %s problem, got %+v", tt.code, p)
+			}
+			if p.RequestID != "req-123" || p.Instance != tt.path || len(p.Errors) != tt.fields {
+				t.Errorf("unexpected problem members: %+v", p)
+			}
+		})
+	}
+}
+
+func TestErrors_RetryHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reset := time.Now().Add(90 * time.Second)
+	r := gin.New()
+	r.Use(RequestID(), Errors())
+	r.GET("/limited", func(c *gin.Context) {
+		response.Error(c, errors.RateLimited("too many requests", errors.RateLimit{Limit: 100, Remaining: 0, Reset: reset}))
+	})
+	r.GET("/unavailable", func(c *gin.Context) {
+		_ = c.Error(errors.Unavailable("database unavailable", nil).WithRetryAfter(30 * time.Second))
+	})
+	r.GET("/conflict", func(c *gin.Context) {
+		response.Error(c, errors.Conflict("already exists").WithRetryAfter(time.Minute))
+	})
+
+	tests := []struct {
+		path    string
+		status  int
+		headers map[string]string
+	}{
+		{
+			path:   "/limited",
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"Retry-After":           "90",
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+		},
+		{
+			path:    "/unavailable",
+			status:  http.StatusServiceUnavailable,
+			headers: map[string]string{"Retry-After": "30", "X-RateLimit-Limit": ""},
+		},
+		{
+			// Retry hints on errors that are not retryable are ignored
+			path:    "/conflict",
+			status:  http.StatusConflict,
+			headers: map[string]string{"Retry-After": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+			for name, want := range tt.headers {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("expected %s %q, got %q", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"generated", "", false},
+		{"propagated", "abc-123", true},
+		{"unsafe replaced", "bad id\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(RequestIDHeader)
+			if tt.keep && got != tt.incoming {
+				t.Errorf("expected %q to be kept, got %q", tt.incoming, got)
+			}
+			if !tt.keep && (got == "" || got == tt.incoming) {
+				t.Errorf("expected a generated ID, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Feature rejects requests while enabled reports false. enabled is read per
+// request so a reloaded toggle applies immediately.
+func Feature(name string, enabled func() bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled() {
+			response.Error(c, errors.Forbidden(name+" is disabled").WithMessageID("feature.disabled", map[string]any{"feature": name}))
+			return
+		}
+		c.Next()
+	}
+}
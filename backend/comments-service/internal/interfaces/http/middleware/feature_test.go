@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	enabled := true
+	r := gin.New()
+	r.GET("/stream", Feature("real-time updates", func() bool { return enabled }), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected enabled feature to pass, got %d", w.Code)
+	}
+
+	// Toggled off by a reload
+	enabled = false
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected disabled feature to be forbidden, got %d", w.Code)
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/idempotency"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+const (
+	// IdempotencyKeyHeader names the client-chosen key of a retryable request
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from the store
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// Idempotency answers retries of a mutating request that carries an
+// Idempotency-Key with the stored response of the first attempt, scoped per
+// tenant and key. Reusing a key for a different request is a conflict.
+// Responses are kept for ttl; a request still running holds the key for at
+// most lockTTL. Server errors are not stored so the client can retry them.
+// Must run after Tenant.
+func Idempotency(store idempotency.Store, ttl, lockTTL time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.Error(c, errors.BadRequest("Idempotency-Key must be at most 255 characters"))
+			return
+		}
+		t, ok := tenant.FromContext(c.Request.Context())
+		if !ok {
+			response.Error(c, errors.Unauthorized("missing tenant"))
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			b, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if stderrors.As(err, &tooLarge) {
+					response.Error(c, errors.PayloadTooLarge("request body too large").WithMessageID("error.body_too_large", nil))
+					return
+				}
+				response.Error(c, errors.BadRequest("failed to read request body"))
+				return
+			}
+			body = b
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+
+		ctx := c.Request.Context()
+		existing, err := store.Reserve(ctx, t.ID, key, fingerprint, lockTTL)
+		if err != nil {
+			response.Error(c, errors.InternalServer("failed to check Idempotency-Key", err))
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				response.Error(c, errors.Conflict("Idempotency-Key was already used for a different request"))
+			case !existing.Completed:
+				response.Error(c, errors.Conflict("a request with this Idempotency-Key is still in progress"))
+			default:
+				replay(c, existing)
+			}
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = rec
+
+		// Outlive a cancelled request so the claim is never left dangling
+		storeCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.Release(storeCtx, t.ID, key); err != nil {
+				log.Printf("failed to release Idempotency-Key: %v", err)
+			}
+		}()
+
+		c.Next()
+
+		if rec.Status() >= http.StatusInternalServerError {
+			return
+		}
+		err = store.Complete(storeCtx, t.ID, key, idempotency.Record{
+			Fingerprint: fingerprint,
+			StatusCode:  rec.Status(),
+			Header:      rec.Header().Clone(),
+			Body:        rec.body.Bytes(),
+		}, ttl)
+		if err != nil {
+			log.Printf("failed to store Idempotency-Key response: %v", err)
+			return
+		}
+		completed = true
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint identifies a request by method, path and body
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, method+" "+path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(c *gin.Context, record *idempotency.Record) {
+	header := c.Writer.Header()
+	for name, values := range record.Header {
+		// The replay is its own request
+		if name != RequestIDHeader {
+			header[name] = values
+		}
+	}
+	header.Set(IdempotentReplayedHeader, "true")
+	c.Status(record.StatusCode)
+	c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// recordingWriter keeps a copy of the response body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/idempotency"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]idempotency.Record)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, tenantID, key, fingerprint string, lockTTL time.Duration) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.records[tenantID+":"+key]; ok {
+		return &r, nil
+	}
+	s.records[tenantID+":"+key] = idempotency.Record{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, tenantID, key string, record idempotency.Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record.Completed = true
+	s.records[tenantID+":"+key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, tenantID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, tenantID+":"+key)
+	return nil
+}
+
+func newIdempotencyRouter(store idempotency.Store, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		t := &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive}
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), t))
+	})
+	r.Use(Idempotency(store, time.Hour, time.Minute))
+	r.POST("/comments", handler)
+	r.GET("/comments", handler)
+	return r
+}
+
+func doIdempotent(r http.Handler, method, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/comments", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	calls := 0
+	r := newIdempotencyRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		c.Header("Location", "/comments/1")
+		c.String(http.StatusCreated, "created %d", calls)
+	})
+
+	first := doIdempotent(r, http.MethodPost, "k1", `{"content":"hi"}`)
+	second := doIdempotent(r, http.MethodPost, "k1", `{"content":"hi"}`)
+
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %d %q, got %d %q", first.Code, first.Body.String(), second.Code, second.Body.String())
+	}
+	if second.Header().Get("Location") != "/comments/1" {
+		t.Errorf("expected replayed headers, got %v", second.Header())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("expected only the retry to be marked as replayed")
+	}
+}
+
+func TestIdempotency_Conflicts(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	r := newIdempotencyRouter(store, func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	doIdempotent(r, http.MethodPost, "k1", `{"content":"hi"}`)
+	if w := doIdempotent(r, http.MethodPost, "k1", `{"content":"other"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a different body, got %d", http.StatusConflict, w.Code)
+	}
+
+	store.Reserve(context.Background(), "tenant-1", "k2", requestFingerprint(http.MethodPost, "/comments", []byte("{}")), time.Minute)
+	if w := doIdempotent(r, http.MethodPost, "k2", `{}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d while in progress, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestIdempotency_ReleasesServerErrors(t *testing.T) {
+	calls := 0
+	r := newIdempotencyRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	doIdempotent(r, http.MethodPost, "k1", `{}`)
+	if w := doIdempotent(r, http.MethodPost, "k1", `{}`); w.Code != http.StatusCreated || calls != 2 {
+		t.Errorf("expected retry after server error to run again, got status %d after %d calls", w.Code, calls)
+	}
+}
+
+func TestIdempotency_PassesThrough(t *testing.T) {
+	calls := 0
+	r := newIdempotencyRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		c.Status(http.StatusOK)
+	})
+
+	doIdempotent(r, http.MethodPost, "", `{}`)
+	doIdempotent(r, http.MethodPost, "", `{}`)
+	doIdempotent(r, http.MethodGet, "k1", "")
+	doIdempotent(r, http.MethodGet, "k1", "")
+
+	if calls != 4 {
+		t.Errorf("expected every request to reach the handler, got %d calls", calls)
+	}
+	if w := doIdempotent(r, http.MethodPost, strings.Repeat("k", 256), `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a long key, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+)
+
+// UserIDHeader names the end user a request is made for, whose preferences
+// choose the language of the response
+const UserIDHeader = "X-User-ID"
+
+// Locale stores the i18n.Locale of the request in its context. The locale
+// is chosen the first time a message is rendered and then sent as
+// Content-Language, so it can run before authentication. The language and
+// timezone preferences of the user named by X-User-ID win when users is set
+// and the tenant is known by then; otherwise Accept-Language decides.
+// Unknown users are not an error, they just fall back to the header, and IDs
+// that are not UUIDs are not looked up. users is queried for every rendered
+// error, so it should be cached.
+func Locale(catalog *i18n.Catalog, users user.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(i18n.NewLazyContext(c.Request.Context(), func() i18n.Locale {
+			// c.Request is read now: later middleware stores the tenant in it
+			ctx := c.Request.Context()
+			var preferred, timezone string
+			if users != nil {
+				t, ok := tenant.FromContext(ctx)
+				if userID := c.GetHeader(UserIDHeader); ok && uuid.Validate(userID) == nil {
+					if prefs, err := users.Preferences(ctx, t.ID, userID); err == nil {
+						preferred, timezone = prefs.Language, prefs.Timezone
+					}
+				}
+			}
+
+			loc := catalog.Locale(preferred, c.GetHeader("Accept-Language"), timezone)
+			c.Header("Content-Language", loc.Language.String())
+			return loc
+		}))
+		c.Next()
+	}
+}
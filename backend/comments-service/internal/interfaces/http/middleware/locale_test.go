@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+)
+
+type fakeUsers map[string]user.Preferences
+
+func (f fakeUsers) Preferences(ctx context.Context, tenantID, userID string) (*user.Preferences, error) {
+	prefs, ok := f[tenantID+"/"+userID]
+	if !ok {
+		return nil, user.ErrNotFound
+	}
+	return &prefs, nil
+}
+
+func TestLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		knownUser   = "0195a1b2-0000-7000-8000-000000000001"
+		unknownUser = "0195a1b2-0000-7000-8000-000000000002"
+	)
+	users := fakeUsers{
+		"t1/" + knownUser: {Language: "de", Timezone: "Europe/Berlin"},
+		// Never looked up: user IDs are UUIDs
+		"t1/u1": {Language: "de"},
+	}
+
+	r := gin.New()
+	r.Use(Errors(), Locale(i18n.Default(), users))
+	// The tenant is only known after Locale, as with the tenant middleware
+	withTenant := func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), &tenant.Tenant{ID: "t1"}))
+	}
+	r.GET("/missing", withTenant, func(c *gin.Context) {
+		response.Error(c, errors.NotFound("comment not found").WithMessageID("comment.not_found", nil))
+	})
+	r.GET("/invalid", func(c *gin.Context) {
+		response.Error(c, errors.Validation(errors.FieldError{
+			Path: "content", Rule: "max_comment_length", Params: map[string]any{"max": 10}, Message: "exceeds the maximum length",
+		}))
+	})
+	r.GET("/internal", func(c *gin.Context) {
+		_ = c.Error(stderrors.New("secret detail"))
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		headers  map[string]string
+		language string
+		title    string
+		detail   string
+		field    string
+	}{
+		{
+			name:     "english by default",
+			path:     "/missing",
+			language: "en",
+			title:    "Not Found",
+			detail:   "comment not found",
+		},
+		{
+			name:     "accept language",
+			path:     "/missing",
+			headers:  map[string]string{"Accept-Language": "fr-CA, fr;q=0.9"},
+			language: "fr",
+			title:    "Introuvable",
+			detail:   "commentaire introuvable",
+		},
+		{
+			name:     "user preference wins",
+			path:     "/missing",
+			headers:  map[string]string{"Accept-Language": "fr", UserIDHeader: knownUser},
+			language: "de",
+			title:    "Nicht gefunden",
+			detail:   "Kommentar nicht gefunden",
+		},
+		{
+			name:     "unknown user falls back to the header",
+			path:     "/missing",
+			headers:  map[string]string{"Accept-Language": "es", UserIDHeader: unknownUser},
+			language: "es",
+			title:    "No encontrado",
+			detail:   "comentario no encontrado",
+		},
+		{
+			name:     "malformed user ID is ignored",
+			path:     "/missing",
+			headers:  map[string]string{"Accept-Language": "fr", UserIDHeader: "u1"},
+			language: "fr",
+			title:    "Introuvable",
+			detail:   "commentaire introuvable",
+		},
+		{
+			name:     "field errors",
+			path:     "/invalid",
+			headers:  map[string]string{"Accept-Language": "es"},
+			language: "es",
+			title:    "Entidad no procesable",
+			detail:   "la validación falló",
+			field:    "supera la longitud máxima de 10 caracteres",
+		},
+		{
+			name:     "internal errors stay generic",
+			path:     "/internal",
+			headers:  map[string]string{"Accept-Language": "de"},
+			language: "de",
+			title:    "Interner Serverfehler",
+			detail:   "interner Serverfehler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Language"); got != tt.language {
+				t.Errorf("expected Content-Language %s, got %s", tt.language, got)
+			}
+			var p response.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if p.Title != tt.title || p.Detail != tt.detail {
+				t.Errorf("expected %q / %q, got %q / %q", tt.title, tt.detail, p.Title, p.Detail)
+			}
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Message != tt.field) {
+				t.Errorf("expected field message %q, got %+v", tt.field, p.Errors)
+			}
+		})
+	}
+}
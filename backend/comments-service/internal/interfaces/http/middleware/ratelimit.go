@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/ratelimit"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// RateLimit counts the requests of the tenant resolved by Tenant per minute
+// and per hour, and answers 429 once either quota is spent. Tenants without
+// limits of their own get the configured defaults. settings is read per
+// request so reloaded limits, and turning limiting off, apply immediately.
+// Every response carries the X-RateLimit-* headers of the quota closest to
+// running out. When the counter fails, requests are let through.
+func RateLimit(counter ratelimit.Counter, settings func() config.RateLimitConfig) gin.HandlerFunc {
+	return rateLimit(counter, settings, time.Now)
+}
+
+func rateLimit(counter ratelimit.Counter, settings func() config.RateLimitConfig, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := settings()
+		t, ok := tenant.FromContext(c.Request.Context())
+		if !cfg.Enabled || !ok {
+			c.Next()
+			return
+		}
+
+		q, ok, err := ratelimit.Take(c.Request.Context(), counter, t.ID, ratelimit.For(t, cfg.PerMinute, cfg.PerHour), now())
+		if err != nil {
+			log.Printf("rate limit of tenant %s not applied: %v", t.ID, err)
+			c.Next()
+			return
+		}
+		quota := errors.RateLimit(q)
+		if !ok {
+			response.Error(c, errors.RateLimited("rate limit exceeded", quota))
+			return
+		}
+
+		response.SetRetryHeaders(c.Writer.Header(), &errors.AppError{RateLimit: &quota})
+		c.Next()
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+)
+
+type memoryCounter struct {
+	counts map[string]int
+	err    error
+}
+
+func (m *memoryCounter) Hit(ctx context.Context, key string, period time.Duration, now time.Time) (int, time.Time, error) {
+	if m.err != nil {
+		return 0, time.Time{}, m.err
+	}
+	start := now.Truncate(period)
+	k := key + period.String() + start.String()
+	m.counts[k]++
+	return m.counts[k], start.Add(period), nil
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// Retry-After is counted from the wall clock
+	now := time.Now().Truncate(time.Minute).Add(15 * time.Second)
+	settings := config.RateLimitConfig{Enabled: true, PerMinute: 2, PerHour: 3}
+	counter := &memoryCounter{counts: map[string]int{}}
+
+	newRouter := func(tn *tenant.Tenant) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), tn))
+		}, rateLimit(counter, func() config.RateLimitConfig { return settings }, func() time.Time { return now }))
+		r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return r
+	}
+	get := func(r *gin.Engine) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w
+	}
+
+	r := newRouter(&tenant.Tenant{ID: "tenant-1"})
+	w := get(r)
+	if w.Code != http.StatusOK || w.Header().Get(response.HeaderRateLimitLimit) != "2" || w.Header().Get(response.HeaderRateLimitRemaining) != "1" {
+		t.Errorf("expected the minute quota in the headers, got %d %v", w.Code, w.Header())
+	}
+	get(r)
+	w = get(r)
+	if w.Code != http.StatusTooManyRequests || w.Header().Get(response.HeaderRetryAfter) == "" {
+		t.Errorf("expected 429 until the next minute, got %d %v", w.Code, w.Header())
+	}
+	if reset := strconv.FormatInt(now.Truncate(time.Minute).Add(time.Minute).Unix(), 10); w.Header().Get(response.HeaderRateLimitReset) != reset {
+		t.Errorf("expected reset %s, got %s", reset, w.Header().Get(response.HeaderRateLimitReset))
+	}
+
+	// The hour quota holds across minutes; the rejected request was not counted
+	now = now.Add(time.Minute)
+	get(r)
+	if w := get(r); w.Code != http.StatusTooManyRequests || w.Header().Get(response.HeaderRateLimitLimit) != "3" {
+		t.Errorf("expected the hour quota to be spent, got %d %v", w.Code, w.Header())
+	}
+
+	// Limits of the tenant beat the defaults
+	if w := get(newRouter(&tenant.Tenant{ID: "tenant-2", RateLimitPerMinute: 10, RateLimitPerHour: 100})); w.Header().Get(response.HeaderRateLimitLimit) != "10" {
+		t.Errorf("expected the tenant's own limit, got %v", w.Header())
+	}
+
+	// Reloaded settings apply to the next request
+	settings.Enabled = false
+	if w := get(r); w.Code != http.StatusOK {
+		t.Errorf("expected limiting to be off, got %d", w.Code)
+	}
+
+	settings.Enabled = true
+	counter.err = stderrors.New("redis down")
+	if w := get(r); w.Code != http.StatusOK {
+		t.Errorf("expected requests through while the counter fails, got %d", w.Code)
+	}
+}
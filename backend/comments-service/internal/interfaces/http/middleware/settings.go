@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+// TenantSettings stores the settings of the tenant loaded by Tenant in the
+// request context, for settings.For. They come from cache, which may be nil
+// to decode them on every request.
+func TenantSettings(cache *apptenant.SettingsCache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, ok := tenant.FromContext(c.Request.Context())
+		if !ok {
+			c.Next()
+			return
+		}
+		c.Request = c.Request.WithContext(settings.NewContext(c.Request.Context(), cache.Resolve(t)))
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+// Package middleware contains the gin middleware shared by the HTTP API.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+)
+
+// APIKeyHeader carries the tenant API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyQuery carries the tenant API key on WebSocket handshakes, where
+// browsers cannot set headers
+const APIKeyQuery = "api_key"
+
+// Tenant resolves the tenant from the API key header and stores it in the
+// request context. Requests without a valid key for an active tenant are
+// rejected.
+func Tenant(auth *apptenant.Authenticator) gin.HandlerFunc {
+	return tenantFrom(auth, func(c *gin.Context) string {
+		return c.GetHeader(APIKeyHeader)
+	})
+}
+
+// WebSocketTenant is Tenant for WebSocket handshakes. It also accepts the
+// key from the api_key query parameter.
+func WebSocketTenant(auth *apptenant.Authenticator) gin.HandlerFunc {
+	return tenantFrom(auth, func(c *gin.Context) string {
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			return key
+		}
+		return c.Query(APIKeyQuery)
+	})
+}
+
+func tenantFrom(auth *apptenant.Authenticator, apiKey func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, err := auth.Authenticate(c.Request.Context(), apiKey(c))
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), t))
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/pkg/apikey"
+)
+
+// fakeTenants knows the key "key", stored as an unkeyed hash
+type fakeTenants struct {
+	hasher *apikey.Hasher
+}
+
+func (f fakeTenants) FindByAPIKeyHash(ctx context.Context, hashes []string) (*tenant.Tenant, string, error) {
+	stored := f.hasher.Hash("key").Value
+	if !slices.Contains(hashes, stored) {
+		return nil, "", tenant.ErrNotFound
+	}
+	return &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive}, stored, nil
+}
+
+func (fakeTenants) RehashAPIKey(ctx context.Context, oldHash, newHash string, pepperVersion int) error {
+	return nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hasher, _ := apikey.NewHasher(nil, true)
+	auth := apptenant.NewAuthenticator(fakeTenants{hasher: hasher}, hasher)
+
+	tests := []struct {
+		name       string
+		middleware gin.HandlerFunc
+		header     string
+		query      string
+		code       int
+	}{
+		{"header", Tenant(auth), "key", "", http.StatusOK},
+		{"missing", Tenant(auth), "", "", http.StatusUnauthorized},
+		{"invalid", Tenant(auth), "other", "", http.StatusUnauthorized},
+		{"query ignored for REST", Tenant(auth), "", "key", http.StatusUnauthorized},
+		{"websocket query", WebSocketTenant(auth), "", "key", http.StatusOK},
+		{"websocket header", WebSocketTenant(auth), "key", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/", tt.middleware, func(c *gin.Context) {
+				if _, ok := tenant.FromContext(c.Request.Context()); !ok {
+					t.Error("expected tenant in context")
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+APIKeyQuery+"="+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set(APIKeyHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, w.Code)
+			}
+		})
+	}
+}
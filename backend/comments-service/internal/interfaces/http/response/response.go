@@ -0,0 +1,161 @@
+// Package response renders handler results and AppErrors consistently.
+// Errors are RFC 7807 problem details.
+package response
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+)
+
+const (
+	// ProblemContentType is the media type of error responses
+	ProblemContentType = "application/problem+json"
+	// ProblemTypeBase prefixes the type URI of every problem; the ErrCode
+	// follows in kebab case, as in .../problems/not-found
+	ProblemTypeBase = "https://comments-api.com/problems/"
+
+	// RequestIDKey is the gin context key holding the request ID
+	RequestIDKey = "request_id"
+
+	// Headers carrying the retry hints of an AppError
+	HeaderRetryAfter         = "Retry-After"
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// Problem is an RFC 7807 problem details object. Code and RequestID are
+// extension members.
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	Code      string              `json:"code"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []errors.FieldError `json:"errors,omitempty"`
+}
+
+// Error aborts the request with err rendered as a problem. Errors that are
+// not AppErrors, and the causes wrapped by AppErrors, are recorded on the
+// context for the error middleware to log but never sent to the client.
+func Error(c *gin.Context, err error) {
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) {
+		appErr = errors.InternalServer("internal server error", err)
+	}
+	if appErr.Err != nil {
+		_ = c.Error(appErr)
+	}
+	c.Abort()
+	SetRetryHeaders(c.Writer.Header(), appErr)
+	WriteProblem(c, NewProblem(c, appErr))
+}
+
+// SetRetryHeaders sends the quota of a rate-limited error as
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix
+// seconds), and the RetryAfter hint of a retryable one as Retry-After in
+// whole seconds
+func SetRetryHeaders(h http.Header, appErr *errors.AppError) {
+	if rl := appErr.RateLimit; rl != nil {
+		h.Set(HeaderRateLimitLimit, strconv.Itoa(rl.Limit))
+		h.Set(HeaderRateLimitRemaining, strconv.Itoa(max(rl.Remaining, 0)))
+		h.Set(HeaderRateLimitReset, strconv.FormatInt(rl.Reset.Unix(), 10))
+	}
+	if !appErr.Retryable() {
+		return
+	}
+	if d := appErr.RetryAfter(); d > 0 {
+		h.Set(HeaderRetryAfter, strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+}
+
+// NewProblem describes appErr for the client in the language of the
+// request's locale. Internal errors only carry a generic detail.
+func NewProblem(c *gin.Context, appErr *errors.AppError) Problem {
+	p := Problem{
+		Type:      TypeURI(appErr.Code),
+		Title:     http.StatusText(appErr.StatusCode),
+		Status:    appErr.StatusCode,
+		Detail:    appErr.Message,
+		Instance:  c.Request.URL.Path,
+		Code:      appErr.Code,
+		RequestID: c.GetString(RequestIDKey),
+		Errors:    appErr.Errors,
+	}
+	messageID, args := appErr.MessageID, appErr.Args
+	if appErr.StatusCode >= http.StatusInternalServerError && appErr.Code == errors.ErrCodeInternalServer {
+		p.Detail = "internal server error"
+		messageID, args = "error.internal", nil
+	}
+
+	// Messages written in code are already in the default language
+	loc := i18n.FromContext(c.Request.Context())
+	if loc.IsDefault() {
+		return p
+	}
+	p.Title = translate(loc, "errors."+appErr.Code, nil, p.Title)
+	p.Detail = translate(loc, messageID, args, p.Detail)
+	if len(p.Errors) > 0 {
+		p.Errors = make([]errors.FieldError, len(appErr.Errors))
+		for i, fe := range appErr.Errors {
+			fe.Message = translate(loc, "rules."+fe.Rule, fe.Params, fe.Message)
+			p.Errors[i] = fe
+		}
+	}
+	return p
+}
+
+// translate renders the catalog message id, keeping fallback when there is
+// no translation
+func translate(loc i18n.Locale, id string, args map[string]any, fallback string) string {
+	if id == "" {
+		return fallback
+	}
+	if msg, ok := loc.Message(id, args); ok {
+		return msg
+	}
+	return fallback
+}
+
+// WriteProblem sends p unless a response has already been started
+func WriteProblem(c *gin.Context, p Problem) {
+	if c.Writer.Written() {
+		return
+	}
+	c.Render(p.Status, problemRender{p})
+}
+
+// TypeURI returns the problem type URI of an ErrCode* constant
+func TypeURI(code string) string {
+	return ProblemTypeBase + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+// problemRender is gin's JSON renderer with the problem media type
+type problemRender struct {
+	problem Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	body, err := json.Marshal(r.problem)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ProblemContentType)
+}
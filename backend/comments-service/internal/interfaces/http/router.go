@@ -0,0 +1,126 @@
+// Package http wires the gin engine: middleware, handlers and routes.
+package http
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ayushvyasgit/comments-service/api"
+	apptenant "github.com/ayushvyasgit/comments-service/internal/application/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/idempotency"
+	"github.com/ayushvyasgit/comments-service/internal/domain/ratelimit"
+	"github.com/ayushvyasgit/comments-service/internal/domain/user"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/handlers"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/middleware"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/websocket"
+	"github.com/ayushvyasgit/comments-service/pkg/i18n"
+)
+
+// Dependencies are the services the router hands to its handlers
+type Dependencies struct {
+	// Tenants authenticates the API key of tenant routes
+	Tenants *apptenant.Authenticator
+	// Settings caches the resolved settings of each tenant
+	Settings *apptenant.SettingsCache
+	Comments handlers.CommentService
+	Likes    handlers.LikeService
+	// TenantSettings updates the settings of the calling tenant
+	TenantSettings handlers.SettingsService
+	// Subdomains checks names for tenant provisioning
+	Subdomains handlers.SubdomainService
+	Health     handlers.HealthChecker
+	Events     handlers.CommentEventStream
+	// Heartbeat is how often idle event streams are pinged
+	Heartbeat time.Duration
+	Gateway   *websocket.Gateway
+	// Idempotency stores responses to requests sent with an Idempotency-Key,
+	// replayed for IdempotencyTTL
+	Idempotency        idempotency.Store
+	IdempotencyTTL     time.Duration
+	IdempotencyLockTTL time.Duration
+	// RateLimits counts the requests of each tenant against the limits
+	// RateLimit returns; either nil disables rate limiting
+	RateLimits ratelimit.Counter
+	RateLimit  func() config.RateLimitConfig
+	// MaxBodyBytes caps request bodies; zero disables the limit
+	MaxBodyBytes int64
+	// CORS returns the current cross-origin settings; nil disables CORS
+	CORS func() config.CORSConfig
+	// Features returns the current feature toggles; nil enables everything
+	Features func() config.FeaturesConfig
+	// Messages translates error responses; nil uses the embedded catalog
+	Messages *i18n.Catalog
+	// Users supplies the language preferences of the user named by
+	// X-User-ID; nil leaves the choice to Accept-Language
+	Users user.Repository
+}
+
+// NewRouter builds the gin engine with every route registered
+func NewRouter(deps Dependencies) *gin.Engine {
+	messages := deps.Messages
+	if messages == nil {
+		messages = i18n.Default()
+	}
+
+	r := gin.New()
+	r.Use(gin.Logger(), middleware.RequestID(), middleware.Errors(), middleware.Locale(messages, deps.Users))
+	if deps.CORS != nil {
+		r.Use(middleware.CORS(deps.CORS))
+	}
+	r.Use(middleware.BodyLimit(deps.MaxBodyBytes))
+
+	health := handlers.NewHealthHandler(deps.Health)
+	r.GET("/health", health.Live)
+	r.GET("/health/live", health.Live)
+	r.GET("/health/ready", health.Ready)
+	r.GET("/", handlers.Root)
+
+	spec := handlers.NewOpenAPIHandler(api.OpenAPI)
+	r.GET("/openapi.yaml", spec.YAML)
+	r.GET("/openapi.json", spec.JSON)
+
+	limit := func(c *gin.Context) { c.Next() }
+	if deps.RateLimits != nil && deps.RateLimit != nil {
+		limit = middleware.RateLimit(deps.RateLimits, deps.RateLimit)
+	}
+
+	v1 := r.Group("/api/v1",
+		middleware.Tenant(deps.Tenants),
+		limit,
+		middleware.TenantSettings(deps.Settings),
+		middleware.Idempotency(deps.Idempotency, deps.IdempotencyTTL, deps.IdempotencyLockTTL),
+	)
+
+	comments := handlers.NewCommentHandler(deps.Comments)
+	v1.POST("/comments", comments.Create)
+	v1.GET("/comments", comments.List)
+	v1.GET("/comments/tree", comments.Tree)
+	v1.GET("/comments/:id", comments.Get)
+	v1.PATCH("/comments/:id", comments.Update)
+	v1.DELETE("/comments/:id", comments.Delete)
+	v1.GET("/comments/:id/tree", comments.Subtree)
+
+	likes := handlers.NewLikeHandler(deps.Likes)
+	v1.POST("/comments/:id/likes", likes.Like)
+	v1.DELETE("/comments/:id/likes/:user_id", likes.Unlike)
+
+	tenantSettings := handlers.NewSettingsHandler(deps.TenantSettings)
+	v1.GET("/settings", tenantSettings.Get)
+	v1.PATCH("/settings", tenantSettings.Update)
+
+	subdomains := handlers.NewSubdomainHandler(deps.Subdomains)
+	v1.GET("/subdomains/:name", subdomains.Check)
+
+	realtime := middleware.Feature("real-time updates", func() bool {
+		return deps.Features == nil || deps.Features().Realtime
+	})
+	stream := handlers.NewStreamHandler(deps.Events, deps.Heartbeat)
+	v1.GET("/comments/stream", realtime, stream.Stream)
+
+	// Outside the v1 group: handshakes may carry the key in the query
+	r.GET("/api/v1/ws", realtime, middleware.WebSocketTenant(deps.Tenants), limit, middleware.TenantSettings(deps.Settings), deps.Gateway.Serve)
+
+	return r
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ayushvyasgit/comments-service/api"
+)
+
+var openAPIMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// TestRouter_MatchesOpenAPI fails when a route is registered on the engine
+// but missing from api/openapi.yaml, or documented but not registered
+func TestRouter_MatchesOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var doc struct {
+		OpenAPI string                          `yaml:"openapi"`
+		Paths   map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(api.OpenAPI, &doc); err != nil {
+		t.Fatalf("failed to parse OpenAPI document: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.1") {
+		t.Errorf("expected OpenAPI 3.1, got %q", doc.OpenAPI)
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			if openAPIMethods[method] {
+				documented[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range NewRouter(Dependencies{}).Routes() {
+		registered[route.Method+" "+openAPIPath(route.Path)] = true
+	}
+
+	for _, route := range keys(registered) {
+		if !documented[route] {
+			t.Errorf("route %s is not documented in api/openapi.yaml", route)
+		}
+	}
+	for _, route := range keys(documented) {
+		if !registered[route] {
+			t.Errorf("route %s is documented but not registered", route)
+		}
+	}
+}
+
+// openAPIPath converts gin path parameters to OpenAPI templates
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func keys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	stderrors "errors"
+	nethttp "net/http"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+)
+
+// Server is the HTTP listener configured from config.ServerConfig
+type Server struct {
+	srv *nethttp.Server
+}
+
+// NewServer wraps handler in an http.Server with the configured timeouts
+// and header limits
+func NewServer(cfg *config.Config, handler nethttp.Handler) *Server {
+	return &Server{
+		srv: &nethttp.Server{
+			Addr:              cfg.ServerAddr(),
+			Handler:           handler,
+			ReadTimeout:       cfg.Server.ReadTimeout,
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+			WriteTimeout:      cfg.Server.WriteTimeout,
+			IdleTimeout:       cfg.Server.IdleTimeout,
+			MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		},
+	}
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.srv.Addr
+}
+
+// Start serves until Shutdown is called. It returns nil after a clean
+// shutdown.
+func (s *Server) Start() error {
+	if err := s.srv.ListenAndServe(); err != nil && !stderrors.Is(err, nethttp.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish or for ctx to expire
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,142 @@
+// Package validation turns request binding failures into field-level
+// validation errors.
+package validation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/ayushvyasgit/comments-service/pkg/email"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+var registerOnce sync.Once
+
+// Register makes gin's validator name fields by their json or form tag, so
+// violations carry the names clients send, and replaces its email rule with
+// package email's. It may be called more than once.
+func Register() {
+	registerOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return f.Name
+		})
+		_ = v.RegisterValidation("email", func(fl validator.FieldLevel) bool {
+			_, err := email.Parse(fl.Field().String())
+			return err == nil
+		})
+	})
+}
+
+// FromBindError converts an error from gin's ShouldBind* methods. Rule
+// violations and values of the wrong type become a 422 listing every bad
+// field; bodies over the BodyLimit cap are a 413 and bodies that are empty
+// or cannot be parsed at all are a 400. Other errors get a generic message,
+// as they may describe the parser rather than the request.
+func FromBindError(err error) *errors.AppError {
+	var (
+		invalid   validator.ValidationErrors
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+		tooLarge  *http.MaxBytesError
+	)
+	switch {
+	case stderrors.As(err, &invalid):
+		violations := make([]errors.FieldError, len(invalid))
+		for i, fe := range invalid {
+			violations[i] = violation(fe)
+		}
+		return errors.Validation(violations...)
+	case stderrors.As(err, &typeErr) && typeErr.Field != "":
+		return errors.Validation(errors.FieldError{
+			Path:    typeErr.Field,
+			Rule:    "type",
+			Params:  map[string]any{"type": typeErr.Type.String()},
+			Message: "must be a " + typeErr.Type.String(),
+		})
+	case stderrors.As(err, &tooLarge):
+		return errors.PayloadTooLarge("request body too large").WithMessageID("error.body_too_large", nil)
+	case stderrors.Is(err, io.EOF):
+		return errors.BadRequest("request body is empty").WithMessageID("error.empty_body", nil)
+	case stderrors.As(err, &syntaxErr), stderrors.Is(err, io.ErrUnexpectedEOF):
+		return errors.BadRequest("request body is not valid JSON").WithMessageID("error.invalid_json", nil)
+	}
+	// The cause is kept for the server log only
+	appErr := errors.BadRequest("request is malformed").WithMessageID("error.malformed_request", nil)
+	appErr.Err = err
+	return appErr
+}
+
+// violation describes one failed validator rule
+func violation(fe validator.FieldError) errors.FieldError {
+	path := fe.Namespace()
+	// The namespace starts with the Go name of the bound struct
+	if _, rest, ok := strings.Cut(path, "."); ok {
+		path = rest
+	}
+	v := errors.FieldError{Path: path, Rule: fe.Tag()}
+
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		v.Message = "is required"
+	case "max", "min", "len":
+		n, _ := strconv.Atoi(param)
+		v.Params = map[string]any{fe.Tag(): n}
+		v.Message = fmt.Sprintf("must be %s %d", boundWord(fe.Tag()), n)
+		if fe.Kind() == reflect.String {
+			v.Message += " characters"
+		}
+	case "oneof":
+		values := strings.Fields(param)
+		v.Params = map[string]any{"values": values}
+		v.Message = "must be one of " + strings.Join(values, ", ")
+	case "uuid", "uuid4":
+		v.Message = "must be a UUID"
+	case "email":
+		value := reflect.Indirect(reflect.ValueOf(fe.Value()))
+		_, err := email.Parse(value.String())
+		if reason := email.ReasonOf(err); reason != "" {
+			v.Params = map[string]any{"reason": string(reason)}
+		}
+		v.Message = "must be an email address"
+	default:
+		if param != "" {
+			v.Params = map[string]any{fe.Tag(): param}
+		}
+		v.Message = "failed the " + fe.Tag() + " rule"
+	}
+	return v
+}
+
+func boundWord(tag string) string {
+	switch tag {
+	case "max":
+		return "at most"
+	case "min":
+		return "at least"
+	}
+	return "exactly"
+}
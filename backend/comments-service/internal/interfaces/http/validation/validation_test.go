@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+type request struct {
+	Name  string `json:"name" binding:"required,max=5"`
+	Sort  string `form:"sort" binding:"omitempty,oneof=new old"`
+	Count int    `json:"count" binding:"max=10"`
+}
+
+func TestFromBindError(t *testing.T) {
+	Register()
+	err := binding.Validator.ValidateStruct(&request{Name: "toolong", Sort: "hot", Count: 11})
+	appErr := FromBindError(err)
+	if appErr.StatusCode != http.StatusUnprocessableEntity || appErr.Code != errors.ErrCodeValidation {
+		t.Fatalf("expected 422 validation error, got %+v", appErr)
+	}
+
+	want := []errors.FieldError{
+		{Path: "name", Rule: "max", Message: "must be at most 5 characters"},
+		{Path: "sort", Rule: "oneof", Message: "must be one of new, old"},
+		{Path: "count", Rule: "max", Message: "must be at most 10"},
+	}
+	if len(appErr.Errors) != len(want) {
+		t.Fatalf("expected %d violations, got %+v", len(want), appErr.Errors)
+	}
+	for i, w := range want {
+		got := appErr.Errors[i]
+		if got.Path != w.Path || got.Rule != w.Rule || got.Message != w.Message {
+			t.Errorf("expected %+v, got %+v", w, got)
+		}
+	}
+	if appErr.Errors[0].Params["max"] != 5 {
+		t.Errorf("expected max param 5, got %v", appErr.Errors[0].Params)
+	}
+}
+
+func TestFromBindError_Body(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		messageID string
+	}{
+		{"empty", io.EOF, http.StatusBadRequest, "error.empty_body"},
+		{"syntax", json.Unmarshal([]byte("{"), &request{}), http.StatusBadRequest, "error.invalid_json"},
+		{"truncated", json.NewDecoder(strings.NewReader(`{"name":`)).Decode(&request{}), http.StatusBadRequest, "error.invalid_json"},
+		{"too large", &http.MaxBytesError{Limit: 8}, http.StatusRequestEntityTooLarge, "error.body_too_large"},
+		{"other", stderrors.New("strconv.ParseInt: parsing \"x\": invalid syntax"), http.StatusBadRequest, "error.malformed_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := FromBindError(tt.err)
+			if appErr.StatusCode != tt.status || appErr.MessageID != tt.messageID {
+				t.Errorf("expected %d %s, got %d %s", tt.status, tt.messageID, appErr.StatusCode, appErr.MessageID)
+			}
+			if strings.Contains(appErr.Message, "strconv") {
+				t.Errorf("expected the cause to stay out of the message, got %q", appErr.Message)
+			}
+		})
+	}
+}
+
+type contact struct {
+	Email *string `json:"email" binding:"omitempty,email"`
+}
+
+func TestRegister_Email(t *testing.T) {
+	Register()
+	tests := []struct {
+		email  string
+		reason string
+	}{
+		{email: `"john doe"@example.com`},
+		{email: "user@bücher.de"},
+		{email: "user@example", reason: "domain"},
+		{email: "john doe@example.com", reason: "local_part"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			err := binding.Validator.ValidateStruct(&contact{Email: &tt.email})
+			if tt.reason == "" {
+				if err != nil {
+					t.Errorf("expected %s to be valid, got %v", tt.email, err)
+				}
+				return
+			}
+			appErr := FromBindError(err)
+			if len(appErr.Errors) != 1 || appErr.Errors[0].Rule != "email" || appErr.Errors[0].Params["reason"] != tt.reason {
+				t.Errorf("expected email violation for %s, got %+v", tt.reason, appErr.Errors)
+			}
+		})
+	}
+}
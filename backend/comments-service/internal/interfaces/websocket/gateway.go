@@ -0,0 +1,148 @@
+// Package websocket serves live comment events and thread presence over a
+// bidirectional WebSocket connection.
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/presence"
+	"github.com/ayushvyasgit/comments-service/internal/domain/settings"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/response"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Gateway upgrades requests to WebSocket sessions
+type Gateway struct {
+	events   comment.EventSubscriber
+	presence presence.Store
+	cfg      config.RealtimeConfig
+	cors     func() config.CORSConfig
+	upgrader gorilla.Upgrader
+
+	mu       sync.Mutex
+	sessions map[*session]struct{}
+	closed   bool
+}
+
+// NewGateway creates a gateway streaming events and presence from the
+// given stores. Browsers may open sessions from their own origin and from
+// the CORS allowed origins, read per handshake so reloads apply; a nil cors
+// allows only the former.
+func NewGateway(events comment.EventSubscriber, store presence.Store, cfg config.RealtimeConfig, cors func() config.CORSConfig) *Gateway {
+	g := &Gateway{
+		events:   events,
+		presence: store,
+		cfg:      cfg,
+		cors:     cors,
+		sessions: make(map[*session]struct{}),
+	}
+	g.upgrader = gorilla.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		CheckOrigin:     g.originAllowed,
+	}
+	return g
+}
+
+// originAllowed keeps pages on other origins from opening sessions with an
+// API key they picked up, as the api_key query parameter makes easy.
+// Requests without an Origin do not come from a browser page.
+func (g *Gateway) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	if g.cors == nil {
+		return false
+	}
+	allowed := g.cors().AllowedOrigins
+	return slices.Contains(allowed, origin) || slices.Contains(allowed, "*")
+}
+
+// Serve handles GET /api/v1/ws. Sessions are authorized by tenant API key
+// only; end-user tokens are not accepted.
+func (g *Gateway) Serve(c *gin.Context) {
+	t, ok := tenant.FromContext(c.Request.Context())
+	if !ok {
+		response.Error(c, errors.Unauthorized("missing tenant"))
+		return
+	}
+	if !settings.For(c.Request.Context()).Features.RealTimeEnabled {
+		response.Error(c, errors.Forbidden("real-time updates are not enabled for this tenant").WithMessageID("realtime.disabled", nil))
+		return
+	}
+	if !g.originAllowed(c.Request) {
+		response.Error(c, errors.Forbidden("origin is not allowed to open real-time sessions").WithMessageID("realtime.origin_not_allowed", nil))
+		return
+	}
+
+	ws, err := g.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the handshake error
+		return
+	}
+
+	// The session must not end with the request context, which the server
+	// cancels once the handler returns from a hijacked connection
+	s := newSession(g, t, ws)
+	if !g.register(s) {
+		s.closeWith(gorilla.CloseGoingAway, "server shutting down")
+		return
+	}
+	defer g.unregister(s)
+	s.run()
+}
+
+func (g *Gateway) register(s *session) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return false
+	}
+	g.sessions[s] = struct{}{}
+	return true
+}
+
+func (g *Gateway) unregister(s *session) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.sessions, s)
+}
+
+// Shutdown tells every connected client the server is going away and stops
+// accepting new sessions. Hijacked connections are not tracked by
+// http.Server, so they have to be closed here.
+func (g *Gateway) Shutdown(ctx context.Context) {
+	g.mu.Lock()
+	g.closed = true
+	sessions := make([]*session, 0, len(g.sessions))
+	for s := range g.sessions {
+		sessions = append(sessions, s)
+	}
+	g.mu.Unlock()
+
+	for _, s := range sessions {
+		s.closeWith(gorilla.CloseGoingAway, "server shutting down")
+	}
+	for _, s := range sessions {
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/ayushvyasgit/comments-service/internal/config"
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/presence"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+)
+
+type fakeEvents struct {
+	mu   sync.Mutex
+	subs map[string]chan comment.Event
+}
+
+func (f *fakeEvents) Subscribe(ctx context.Context, tenantID, entityType, entityID, lastEventID string) (<-chan comment.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan comment.Event, 8)
+	f.subs[entityType+"/"+entityID] = ch
+	return ch, nil
+}
+
+func (f *fakeEvents) publish(thread string, e comment.Event) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch, ok := f.subs[thread]
+	if ok {
+		ch <- e
+	}
+	return ok
+}
+
+type fakePresence struct {
+	mu      sync.Mutex
+	viewers map[presence.Thread]map[string]bool
+	typing  map[presence.Thread]map[string]bool
+}
+
+func newFakePresence() *fakePresence {
+	return &fakePresence{
+		viewers: make(map[presence.Thread]map[string]bool),
+		typing:  make(map[presence.Thread]map[string]bool),
+	}
+}
+
+func (f *fakePresence) mark(m map[presence.Thread]map[string]bool, t presence.Thread, session string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if m[t] == nil {
+		m[t] = make(map[string]bool)
+	}
+	m[t][session] = true
+}
+
+func (f *fakePresence) Join(ctx context.Context, t presence.Thread, session string, ttl time.Duration) error {
+	f.mark(f.viewers, t, session)
+	return nil
+}
+
+func (f *fakePresence) Typing(ctx context.Context, t presence.Thread, session string, ttl time.Duration) error {
+	f.mark(f.typing, t, session)
+	return nil
+}
+
+func (f *fakePresence) Leave(ctx context.Context, t presence.Thread, session string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.viewers[t], session)
+	delete(f.typing[t], session)
+	return nil
+}
+
+func (f *fakePresence) Snapshots(ctx context.Context, threads []presence.Thread) (map[presence.Thread]presence.Snapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[presence.Thread]presence.Snapshot, len(threads))
+	for _, t := range threads {
+		out[t] = presence.Snapshot{Viewers: len(f.viewers[t]), Typing: len(f.typing[t])}
+	}
+	return out, nil
+}
+
+func newTestGateway(t *testing.T, features string) (*httptest.Server, *fakeEvents, *Gateway) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	events := &fakeEvents{subs: make(map[string]chan comment.Event)}
+	gw := NewGateway(events, newFakePresence(), config.RealtimeConfig{
+		Heartbeat:        time.Minute,
+		PresenceTTL:      time.Minute,
+		TypingTTL:        time.Second,
+		MaxSubscriptions: 2,
+	}, func() config.CORSConfig {
+		return config.CORSConfig{AllowedOrigins: []string{"https://app.example"}}
+	})
+
+	r := gin.New()
+	r.GET("/ws", func(c *gin.Context) {
+		tn := &tenant.Tenant{ID: "tenant-1", Status: tenant.StatusActive, Features: json.RawMessage(features)}
+		c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), tn))
+	}, gw.Serve)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, events, gw
+}
+
+func dial(t *testing.T, srv *httptest.Server) *gorilla.Conn {
+	t.Helper()
+	conn, _, err := gorilla.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// next reads messages until one of type want arrives
+func next(t *testing.T, conn *gorilla.Conn, want string) ServerMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg ServerMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed waiting for %s: %v", want, err)
+		}
+		if msg.Type == want {
+			return msg
+		}
+	}
+}
+
+func TestGateway_FeatureDisabled(t *testing.T) {
+	srv, _, _ := newTestGateway(t, `{}`)
+
+	_, resp, err := gorilla.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	if err == nil {
+		t.Fatal("expected the handshake to fail")
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestGateway_Origin(t *testing.T) {
+	srv, _, _ := newTestGateway(t, `{"real_time_enabled": true}`)
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	tests := []struct {
+		origin string
+		code   int
+	}{
+		{"https://app.example", http.StatusSwitchingProtocols},
+		{"http://" + host, http.StatusSwitchingProtocols},
+		{"https://evil.example", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			conn, resp, _ := gorilla.DefaultDialer.Dial("ws://"+host+"/ws", http.Header{"Origin": {tt.origin}})
+			if conn != nil {
+				conn.Close()
+			}
+			if resp == nil || resp.StatusCode != tt.code {
+				t.Errorf("expected status %d, got %v", tt.code, resp)
+			}
+		})
+	}
+}
+
+func TestGateway_SubscribeEventsAndPresence(t *testing.T) {
+	srv, events, _ := newTestGateway(t, `{"real_time_enabled": true}`)
+	conn := dial(t, srv)
+
+	conn.WriteJSON(ClientMessage{Type: TypeSubscribe, EntityType: "post", EntityID: "post_1"})
+	if msg := next(t, conn, TypeSubscribed); msg.EntityID != "post_1" {
+		t.Errorf("expected subscription to post_1, got %+v", msg)
+	}
+
+	events.publish("post/post_1", comment.Event{ID: "1-0", Type: comment.EventCreated, CommentID: "c1"})
+	msg := next(t, conn, TypeEvent)
+	if msg.ID != "1-0" || msg.Event == nil || msg.Event.CommentID != "c1" {
+		t.Errorf("unexpected event message %+v", msg)
+	}
+
+	conn.WriteJSON(ClientMessage{Type: TypeTyping, EntityType: "post", EntityID: "post_1"})
+	for {
+		msg = next(t, conn, TypePresence)
+		if *msg.Viewers == 1 && *msg.Typing == 1 {
+			break
+		}
+	}
+}
+
+func TestGateway_Errors(t *testing.T) {
+	srv, _, _ := newTestGateway(t, `{"real_time_enabled": true}`)
+	conn := dial(t, srv)
+
+	conn.WriteMessage(gorilla.TextMessage, []byte("not json"))
+	if msg := next(t, conn, TypeError); msg.Error.Message != "invalid message" {
+		t.Errorf("unexpected error %+v", msg.Error)
+	}
+
+	conn.WriteJSON(ClientMessage{Type: TypeTyping, EntityType: "post", EntityID: "post_1"})
+	if msg := next(t, conn, TypeError); msg.Error.Message != "not subscribed to this thread" {
+		t.Errorf("unexpected error %+v", msg.Error)
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		conn.WriteJSON(ClientMessage{Type: TypeSubscribe, EntityType: "post", EntityID: id})
+	}
+	if msg := next(t, conn, TypeError); msg.Error.Message != "too many subscriptions" {
+		t.Errorf("unexpected error %+v", msg.Error)
+	}
+}
+
+func TestGateway_Shutdown(t *testing.T) {
+	srv, _, gw := newTestGateway(t, `{"real_time_enabled": true}`)
+	conn := dial(t, srv)
+
+	conn.WriteJSON(ClientMessage{Type: TypePing})
+	next(t, conn, TypePong)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	gw.Shutdown(ctx)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !gorilla.IsCloseError(err, gorilla.CloseGoingAway) {
+		t.Errorf("expected a going away close, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package websocket
+
+import (
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/dto"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Client message types
+const (
+	TypeSubscribe   = "subscribe"
+	TypeUnsubscribe = "unsubscribe"
+	TypeTyping      = "typing"
+	TypePing        = "ping"
+)
+
+// Server message types
+const (
+	TypeSubscribed   = "subscribed"
+	TypeUnsubscribed = "unsubscribed"
+	TypeEvent        = "event"
+	TypePresence     = "presence"
+	TypePong         = "pong"
+	TypeError        = "error"
+)
+
+// ClientMessage is any message a client sends. Thread fields are required
+// for everything but ping.
+type ClientMessage struct {
+	Type       string `json:"type"`
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	// LastEventID resumes a subscription after the given event
+	LastEventID string `json:"last_event_id,omitempty"`
+}
+
+// ServerMessage is any message the gateway sends
+type ServerMessage struct {
+	Type       string                    `json:"type"`
+	EntityType string                    `json:"entity_type,omitempty"`
+	EntityID   string                    `json:"entity_id,omitempty"`
+	ID         string                    `json:"id,omitempty"`
+	Event      *dto.CommentEventResponse `json:"event,omitempty"`
+	Viewers    *int                      `json:"viewers,omitempty"`
+	Typing     *int                      `json:"typing,omitempty"`
+	Error      *errors.AppError          `json:"error,omitempty"`
+}
@@ -0,0 +1,367 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/ayushvyasgit/comments-service/internal/domain/comment"
+	"github.com/ayushvyasgit/comments-service/internal/domain/presence"
+	"github.com/ayushvyasgit/comments-service/internal/domain/tenant"
+	"github.com/ayushvyasgit/comments-service/internal/interfaces/http/dto"
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+const (
+	// maxMessageBytes caps a single client message
+	maxMessageBytes = 4096
+	writeWait       = 10 * time.Second
+	// sendBuffer is how many messages may queue for a client before the
+	// session is closed as too slow
+	sendBuffer = 64
+	// presenceInterval is how often viewer and typing counts are polled
+	presenceInterval = 2 * time.Second
+	// typingInterval throttles typing notices per thread
+	typingInterval = time.Second
+)
+
+// session is one WebSocket connection. Only the write loop writes to ws.
+type session struct {
+	id     string
+	gw     *Gateway
+	tenant *tenant.Tenant
+	ws     *gorilla.Conn
+	send   chan ServerMessage
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+
+	mu      sync.Mutex
+	threads map[presence.Thread]*thread
+
+	// lastJoin is only touched by the write loop
+	lastJoin time.Time
+}
+
+// thread is a subscription of the session
+type thread struct {
+	cancel     context.CancelFunc
+	lastTyping time.Time
+	snapshot   presence.Snapshot
+	reported   bool
+}
+
+func newSession(gw *Gateway, t *tenant.Tenant, ws *gorilla.Conn) *session {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &session{
+		id:        uuid.NewString(),
+		gw:        gw,
+		tenant:    t,
+		ws:        ws,
+		send:      make(chan ServerMessage, sendBuffer),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		closeCode: gorilla.CloseNormalClosure,
+		threads:   make(map[presence.Thread]*thread),
+	}
+}
+
+// run serves the session until either side closes it
+func (s *session) run() {
+	defer close(s.done)
+
+	go s.writeLoop()
+	s.readLoop()
+	s.cancel()
+
+	s.mu.Lock()
+	threads := make([]presence.Thread, 0, len(s.threads))
+	for t := range s.threads {
+		threads = append(threads, t)
+	}
+	s.mu.Unlock()
+	for _, t := range threads {
+		s.drop(t)
+	}
+}
+
+// closeWith ends the session, sending code to the client
+func (s *session) closeWith(code int, text string) {
+	s.closeOnce.Do(func() {
+		s.closeCode, s.closeText = code, text
+		s.cancel()
+	})
+}
+
+func (s *session) readLoop() {
+	pongWait := 2 * s.gw.cfg.Heartbeat
+	s.ws.SetReadLimit(maxMessageBytes)
+	s.ws.SetReadDeadline(time.Now().Add(pongWait))
+	s.ws.SetPongHandler(func(string) error {
+		return s.ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := s.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.ws.SetReadDeadline(time.Now().Add(pongWait))
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.fail(errors.BadRequest("invalid message"))
+			continue
+		}
+		s.handle(msg)
+	}
+}
+
+func (s *session) handle(msg ClientMessage) {
+	if msg.Type == TypePing {
+		s.enqueue(ServerMessage{Type: TypePong})
+		return
+	}
+
+	t, ok := s.threadOf(msg)
+	if !ok {
+		return
+	}
+	switch msg.Type {
+	case TypeSubscribe:
+		s.subscribe(t, msg.LastEventID)
+	case TypeUnsubscribe:
+		s.drop(t)
+		s.enqueue(ServerMessage{Type: TypeUnsubscribed, EntityType: t.EntityType, EntityID: t.EntityID})
+	case TypeTyping:
+		s.typing(t)
+	default:
+		s.fail(errors.BadRequest("unknown message type"))
+	}
+}
+
+func (s *session) threadOf(msg ClientMessage) (presence.Thread, bool) {
+	if strings.TrimSpace(msg.EntityType) == "" || strings.TrimSpace(msg.EntityID) == "" {
+		s.fail(errors.BadRequest("entity_type and entity_id are required"))
+		return presence.Thread{}, false
+	}
+	return presence.Thread{TenantID: s.tenant.ID, EntityType: msg.EntityType, EntityID: msg.EntityID}, true
+}
+
+func (s *session) subscribe(t presence.Thread, lastEventID string) {
+	s.mu.Lock()
+	if _, ok := s.threads[t]; ok {
+		s.mu.Unlock()
+		s.enqueue(ServerMessage{Type: TypeSubscribed, EntityType: t.EntityType, EntityID: t.EntityID})
+		return
+	}
+	if len(s.threads) >= s.gw.cfg.MaxSubscriptions {
+		s.mu.Unlock()
+		s.fail(errors.BadRequest("too many subscriptions"))
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.threads[t] = &thread{cancel: cancel}
+	s.mu.Unlock()
+
+	events, err := s.gw.events.Subscribe(ctx, t.TenantID, t.EntityType, t.EntityID, lastEventID)
+	if err != nil {
+		s.drop(t)
+		s.fail(errors.InternalServer("failed to subscribe to comment events", err))
+		return
+	}
+	if err := s.gw.presence.Join(ctx, t, s.id, s.gw.cfg.PresenceTTL); err != nil {
+		log.Printf("failed to join presence for %s/%s: %v", t.EntityType, t.EntityID, err)
+	}
+
+	s.enqueue(ServerMessage{Type: TypeSubscribed, EntityType: t.EntityType, EntityID: t.EntityID})
+	go s.forward(ctx, t, events)
+}
+
+// forward relays the events of one subscription. If the stream ends while
+// the subscription is still wanted, the client is told to resubscribe with
+// the last event id it saw.
+func (s *session) forward(ctx context.Context, t presence.Thread, events <-chan comment.Event) {
+	for e := range events {
+		resp := dto.ToCommentEventResponse(e)
+		s.enqueue(ServerMessage{
+			Type:       TypeEvent,
+			EntityType: t.EntityType,
+			EntityID:   t.EntityID,
+			ID:         e.ID,
+			Event:      &resp,
+		})
+	}
+	if ctx.Err() == nil {
+		s.drop(t)
+		s.enqueue(ServerMessage{Type: TypeUnsubscribed, EntityType: t.EntityType, EntityID: t.EntityID})
+	}
+}
+
+// drop ends a subscription and its presence
+func (s *session) drop(t presence.Thread) {
+	s.mu.Lock()
+	th, ok := s.threads[t]
+	if ok {
+		th.cancel()
+		delete(s.threads, t)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeWait)
+	defer cancel()
+	if err := s.gw.presence.Leave(ctx, t, s.id); err != nil {
+		log.Printf("failed to leave presence for %s/%s: %v", t.EntityType, t.EntityID, err)
+	}
+}
+
+func (s *session) typing(t presence.Thread) {
+	s.mu.Lock()
+	th, ok := s.threads[t]
+	if !ok {
+		s.mu.Unlock()
+		s.fail(errors.BadRequest("not subscribed to this thread"))
+		return
+	}
+	if time.Since(th.lastTyping) < typingInterval {
+		s.mu.Unlock()
+		return
+	}
+	th.lastTyping = time.Now()
+	s.mu.Unlock()
+
+	if err := s.gw.presence.Typing(s.ctx, t, s.id, s.gw.cfg.TypingTTL); err != nil {
+		log.Printf("failed to record typing for %s/%s: %v", t.EntityType, t.EntityID, err)
+	}
+}
+
+func (s *session) fail(err *errors.AppError) {
+	if err.Err != nil {
+		log.Printf("websocket session %s: %v", s.id, err)
+	}
+	s.enqueue(ServerMessage{Type: TypeError, Error: err})
+}
+
+// enqueue queues msg without blocking. A client that cannot keep up is
+// disconnected and expected to resubscribe with its last event id.
+func (s *session) enqueue(msg ServerMessage) {
+	select {
+	case s.send <- msg:
+	case <-s.ctx.Done():
+	default:
+		s.closeWith(gorilla.CloseTryAgainLater, "client too slow")
+	}
+}
+
+func (s *session) writeLoop() {
+	ping := time.NewTicker(s.gw.cfg.Heartbeat)
+	poll := time.NewTicker(presenceInterval)
+	defer func() {
+		ping.Stop()
+		poll.Stop()
+		s.ws.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-s.send:
+			if err := s.write(msg); err != nil {
+				s.cancel()
+				return
+			}
+		case <-ping.C:
+			if err := s.ws.WriteControl(gorilla.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				s.cancel()
+				return
+			}
+		case <-poll.C:
+			if err := s.reportPresence(); err != nil {
+				s.cancel()
+				return
+			}
+		case <-s.ctx.Done():
+			closing := gorilla.FormatCloseMessage(s.closeCode, s.closeText)
+			_ = s.ws.WriteControl(gorilla.CloseMessage, closing, time.Now().Add(writeWait))
+			return
+		}
+	}
+}
+
+func (s *session) write(msg ServerMessage) error {
+	s.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	return s.ws.WriteJSON(msg)
+}
+
+// reportPresence refreshes this session's presence and sends the counts of
+// every thread whose numbers changed. Store failures are logged; only write
+// failures end the session.
+func (s *session) reportPresence() error {
+	s.mu.Lock()
+	threads := make([]presence.Thread, 0, len(s.threads))
+	for t := range s.threads {
+		threads = append(threads, t)
+	}
+	s.mu.Unlock()
+	if len(threads) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, presenceInterval)
+	defer cancel()
+
+	if time.Since(s.lastJoin) >= s.gw.cfg.PresenceTTL/3 {
+		for _, t := range threads {
+			if err := s.gw.presence.Join(ctx, t, s.id, s.gw.cfg.PresenceTTL); err != nil {
+				log.Printf("failed to refresh presence for %s/%s: %v", t.EntityType, t.EntityID, err)
+			}
+		}
+		s.lastJoin = time.Now()
+	}
+
+	snapshots, err := s.gw.presence.Snapshots(ctx, threads)
+	if err != nil {
+		log.Printf("failed to read presence: %v", err)
+		return nil
+	}
+
+	var changed []ServerMessage
+	s.mu.Lock()
+	for t, snap := range snapshots {
+		th, ok := s.threads[t]
+		if !ok || (th.reported && th.snapshot == snap) {
+			continue
+		}
+		th.snapshot, th.reported = snap, true
+		viewers, typing := snap.Viewers, snap.Typing
+		changed = append(changed, ServerMessage{
+			Type:       TypePresence,
+			EntityType: t.EntityType,
+			EntityID:   t.EntityID,
+			Viewers:    &viewers,
+			Typing:     &typing,
+		})
+	}
+	s.mu.Unlock()
+
+	for _, msg := range changed {
+		if err := s.write(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
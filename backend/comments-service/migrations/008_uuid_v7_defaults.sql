@@ -0,0 +1,36 @@
+-- Migration: 008_uuid_v7_defaults
+-- Description: Time-ordered UUIDv7 primary keys for append-heavy tables
+-- Author: System
+-- Date: 2025-03-01
+
+-- ============================================================================
+-- UUIDv7 GENERATION
+-- ============================================================================
+
+-- RFC 9562 version 7: 48-bit Unix milliseconds followed by random bits.
+-- The application generates IDs in pkg/id; this default covers rows inserted
+-- from SQL so they keep the same ordering.
+CREATE OR REPLACE FUNCTION uuid_generate_v7()
+RETURNS UUID AS $$
+DECLARE
+    ts BYTEA := substring(int8send((extract(epoch FROM clock_timestamp()) * 1000)::BIGINT) FROM 3);
+    uuid_bytes BYTEA := ts || substring(uuid_send(gen_random_uuid()) FROM 7);
+BEGIN
+    -- Version 7 in the high nibble of byte 6
+    uuid_bytes := set_byte(uuid_bytes, 6, (get_byte(uuid_bytes, 6) & 15) | 112);
+    -- RFC 9562 variant in the top two bits of byte 8
+    uuid_bytes := set_byte(uuid_bytes, 8, (get_byte(uuid_bytes, 8) & 63) | 128);
+    RETURN encode(uuid_bytes, 'hex')::UUID;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+COMMENT ON FUNCTION uuid_generate_v7() IS 'Generates a time-ordered RFC 9562 version 7 UUID';
+
+-- ============================================================================
+-- DEFAULTS
+-- ============================================================================
+
+ALTER TABLE comments ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE likes ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE comment_edits ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE audit_logs ALTER COLUMN id SET DEFAULT uuid_generate_v7();
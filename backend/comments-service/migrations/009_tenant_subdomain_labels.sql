@@ -0,0 +1,19 @@
+-- Migration: 009_tenant_subdomain_labels
+-- Description: Hold tenant subdomains to the RFC 1123 label rules enforced by pkg/subdomain
+-- Author: System
+-- Date: 2025-03-08
+
+-- ============================================================================
+-- SUBDOMAIN LABEL RULES
+-- ============================================================================
+
+-- A DNS label is at most 63 characters and cannot start or end with a
+-- hyphen; internationalized names are stored as lowercase punycode
+ALTER TABLE tenants
+    ADD CONSTRAINT tenants_subdomain_label CHECK (
+        char_length(subdomain) <= 63 AND
+        subdomain = lower(subdomain) AND
+        subdomain !~ '^-|-$'
+    ) NOT VALID;
+
+COMMENT ON CONSTRAINT tenants_subdomain_label ON tenants IS 'Subdomain must be a lowercase DNS label of at most 63 characters without leading or trailing hyphens';
@@ -0,0 +1,21 @@
+-- Migration: 010_api_key_peppers
+-- Description: Record which pepper each API key hash was made with, for pkg/apikey rotation
+-- Author: System
+-- Date: 2025-03-09
+
+-- ============================================================================
+-- API KEY PEPPER VERSIONS
+-- ============================================================================
+
+-- Existing hashes are unkeyed SHA-256, version 0. Keys move to the current
+-- pepper the next time they are used; a pepper can be retired once no
+-- active key is left on its version.
+ALTER TABLE api_keys
+    ADD COLUMN pepper_version SMALLINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT api_keys_pepper_version_valid CHECK (pepper_version >= 0);
+
+CREATE INDEX idx_api_keys_pepper_version ON api_keys(pepper_version) WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE api_keys IS 'API keys for tenant authentication (keys stored as HMAC-SHA256 hashes under a server-side pepper)';
+COMMENT ON COLUMN api_keys.key_hash IS 'HMAC-SHA256 of the API key under pepper_version, or SHA-256 for version 0';
+COMMENT ON COLUMN api_keys.pepper_version IS 'Version of the pepper key_hash was made with; 0 is unkeyed SHA-256';
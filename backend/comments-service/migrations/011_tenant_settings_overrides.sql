@@ -0,0 +1,51 @@
+-- Migration: 011_tenant_settings_overrides
+-- Description: Store only tenant overrides in tenants.features and tenants.settings so plan defaults apply
+-- Author: System
+-- Date: 2025-03-10
+
+-- ============================================================================
+-- TENANT FEATURES AND SETTINGS HOLD OVERRIDES ONLY
+-- ============================================================================
+
+-- The service merges these columns over the defaults of the tenant's plan
+-- (internal/domain/settings). The column defaults of 001 wrote every key
+-- into every row, so real_time_enabled=false and max_comment_length=10000
+-- beat the plan defaults of every tenant. New rows start empty.
+ALTER TABLE tenants
+    ALTER COLUMN features SET DEFAULT '{}'::jsonb,
+    ALTER COLUMN settings SET DEFAULT '{}'::jsonb;
+
+-- Drop the keys still holding the old column defaults. A tenant that set
+-- one of those values deliberately cannot be told apart from one that never
+-- set it, so such keys resolve from the plan from now on.
+UPDATE tenants
+SET features = COALESCE((
+        SELECT jsonb_object_agg(key, value)
+        FROM jsonb_each(features)
+        WHERE NOT '{
+            "comments_enabled": true,
+            "likes_enabled": true,
+            "real_time_enabled": false,
+            "advanced_moderation": false,
+            "custom_branding": false,
+            "api_access": true,
+            "webhooks_enabled": false,
+            "analytics_enabled": false
+        }'::jsonb @> jsonb_build_object(key, value)
+    ), '{}'::jsonb),
+    settings = COALESCE((
+        SELECT jsonb_object_agg(key, value)
+        FROM jsonb_each(settings)
+        WHERE NOT '{
+            "moderation_mode": "auto",
+            "spam_filter_enabled": true,
+            "profanity_filter_enabled": true,
+            "max_comment_length": 10000,
+            "max_nesting_depth": 10,
+            "allow_anonymous": false,
+            "require_email_verification": true
+        }'::jsonb @> jsonb_build_object(key, value)
+    ), '{}'::jsonb);
+
+COMMENT ON COLUMN tenants.features IS 'Feature flags the tenant overrides; missing flags take the plan default';
+COMMENT ON COLUMN tenants.settings IS 'Settings the tenant overrides; missing settings take the plan default';
@@ -0,0 +1,247 @@
+// Package apikey issues tenant API keys and hashes them for storage.
+//
+// Keys look like cmk_live_ followed by 32 random base62 characters and a
+// 6 character CRC32 checksum. The fixed prefix lets secret scanners find
+// leaked keys with the pattern
+//
+//	\bcmk_(live|test)_[0-9A-Za-z]{38}\b
+//
+// and the checksum lets them, and the service, discard look-alikes without
+// a database lookup.
+//
+// Keys are stored as HMAC-SHA256 hashes under a server-side pepper, so a
+// copy of the api_keys table is not enough to check guessed keys. Peppers
+// are numbered: keys hashed under a retired pepper keep validating, and can
+// be rehashed under the current one, until the old pepper is dropped.
+package apikey
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Prefix starts every key
+const Prefix = "cmk"
+
+// Environments a key can be issued for. Test keys are for sandboxes and CI;
+// the split only helps humans and scanners tell them apart.
+const (
+	EnvLive = "live"
+	EnvTest = "test"
+)
+
+const (
+	alphabet       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	secretLength   = 32
+	checksumLength = 6
+)
+
+var (
+	// ErrMalformed is returned for keys that carry the prefix but are not
+	// well formed, or whose checksum does not match
+	ErrMalformed = errors.New("apikey: malformed key")
+	// ErrUnknownFormat is returned for keys without the prefix, such as
+	// keys issued before it was introduced
+	ErrUnknownFormat = errors.New("apikey: unknown key format")
+)
+
+// Generate returns a new key for env, EnvLive or EnvTest
+func Generate(env string) (string, error) {
+	return generate(env, rand.Reader)
+}
+
+func generate(env string, random io.Reader) (string, error) {
+	if env != EnvLive && env != EnvTest {
+		return "", fmt.Errorf("apikey: unknown environment %q", env)
+	}
+	var b strings.Builder
+	b.WriteString(Prefix + "_" + env + "_")
+	// Bytes at or above 248 are redrawn so every character is equally likely
+	buf := make([]byte, 2*secretLength)
+	for n := 0; n < secretLength; {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return "", fmt.Errorf("apikey: read random: %w", err)
+		}
+		for _, c := range buf {
+			if c < 248 && n < secretLength {
+				b.WriteByte(alphabet[int(c)%len(alphabet)])
+				n++
+			}
+		}
+	}
+	body := b.String()
+	return body + checksum(body), nil
+}
+
+// Check verifies the format and checksum of key. It returns
+// ErrUnknownFormat for keys that do not carry the prefix, which may still
+// be valid keys of the older format.
+func Check(key string) error {
+	_, err := Environment(key)
+	return err
+}
+
+// Environment returns the environment key was issued for, after checking it
+// as Check does
+func Environment(key string) (string, error) {
+	rest, ok := strings.CutPrefix(key, Prefix+"_")
+	if !ok {
+		return "", ErrUnknownFormat
+	}
+	env, token, ok := strings.Cut(rest, "_")
+	if !ok || (env != EnvLive && env != EnvTest) || len(token) != secretLength+checksumLength {
+		return "", ErrMalformed
+	}
+	for i := 0; i < len(token); i++ {
+		if strings.IndexByte(alphabet, token[i]) < 0 {
+			return "", ErrMalformed
+		}
+	}
+	split := len(key) - checksumLength
+	if key[split:] != checksum(key[:split]) {
+		return "", ErrMalformed
+	}
+	return env, nil
+}
+
+// checksum is the CRC32 of body in fixed-width base62
+func checksum(body string) string {
+	n := crc32.ChecksumIEEE([]byte(body))
+	var b [checksumLength]byte
+	for i := checksumLength - 1; i >= 0; i-- {
+		b[i] = alphabet[n%uint32(len(alphabet))]
+		n /= uint32(len(alphabet))
+	}
+	return string(b[:])
+}
+
+// LegacyVersion numbers hashes made with unkeyed SHA-256, before peppers
+const LegacyVersion = 0
+
+// MinPepperLength is the shortest pepper NewHasher accepts, in bytes
+const MinPepperLength = 32
+
+// Pepper is a server-side HMAC key. Version is positive and goes up with
+// every new pepper.
+type Pepper struct {
+	Version int
+	Secret  []byte
+}
+
+// ParsePeppers reads a comma-separated list of version:secret pairs, as in
+// "2:new-secret,1:old-secret". Secrets may not contain commas.
+func ParsePeppers(s string) ([]Pepper, error) {
+	var peppers []Pepper
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		version, secret, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, errors.New("apikey: pepper must be given as version:secret")
+		}
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return nil, fmt.Errorf("apikey: pepper version %q is not an integer", version)
+		}
+		peppers = append(peppers, Pepper{Version: v, Secret: []byte(secret)})
+	}
+	return peppers, nil
+}
+
+// Hash is a key hash ready for api_keys.key_hash, with the version of the
+// pepper that made it
+type Hash struct {
+	Version int
+	Value   string
+}
+
+// Hasher hashes keys under a set of peppers. The highest version is the
+// current pepper, used for new hashes; the others are only used to
+// recognize keys hashed before a rotation.
+type Hasher struct {
+	state atomic.Pointer[hasherState]
+}
+
+type hasherState struct {
+	// peppers are ordered newest first
+	peppers []Pepper
+	legacy  bool
+}
+
+// NewHasher creates a hasher using peppers. allowLegacy also accepts
+// unkeyed SHA-256 hashes; without peppers they are the only hashes made.
+func NewHasher(peppers []Pepper, allowLegacy bool) (*Hasher, error) {
+	h := &Hasher{}
+	if err := h.Rotate(peppers, allowLegacy); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Rotate replaces the peppers, as NewHasher would set them. On error the
+// hasher is left unchanged.
+func (h *Hasher) Rotate(peppers []Pepper, allowLegacy bool) error {
+	if len(peppers) == 0 && !allowLegacy {
+		return errors.New("apikey: at least one pepper is required")
+	}
+	sorted := slices.Clone(peppers)
+	slices.SortFunc(sorted, func(a, b Pepper) int { return b.Version - a.Version })
+	for i, p := range sorted {
+		switch {
+		case p.Version <= LegacyVersion:
+			return fmt.Errorf("apikey: pepper version %d must be positive", p.Version)
+		case i > 0 && sorted[i-1].Version == p.Version:
+			return fmt.Errorf("apikey: pepper version %d is given twice", p.Version)
+		case len(p.Secret) < MinPepperLength:
+			return fmt.Errorf("apikey: pepper version %d must be at least %d bytes", p.Version, MinPepperLength)
+		}
+	}
+	h.state.Store(&hasherState{peppers: sorted, legacy: allowLegacy})
+	return nil
+}
+
+// Hash returns the hash of key to store, under the current pepper
+func (h *Hasher) Hash(key string) Hash {
+	s := h.state.Load()
+	if len(s.peppers) == 0 {
+		return legacyHash(key)
+	}
+	return pepperHash(s.peppers[0], key)
+}
+
+// Candidates returns every hash key may be stored under, current pepper
+// first
+func (h *Hasher) Candidates(key string) []Hash {
+	s := h.state.Load()
+	hashes := make([]Hash, 0, len(s.peppers)+1)
+	for _, p := range s.peppers {
+		hashes = append(hashes, pepperHash(p, key))
+	}
+	if s.legacy {
+		hashes = append(hashes, legacyHash(key))
+	}
+	return hashes
+}
+
+func pepperHash(p Pepper, key string) Hash {
+	mac := hmac.New(sha256.New, p.Secret)
+	mac.Write([]byte(key))
+	return Hash{Version: p.Version, Value: hex.EncodeToString(mac.Sum(nil))}
+}
+
+func legacyHash(key string) Hash {
+	sum := sha256.Sum256([]byte(key))
+	return Hash{Version: LegacyVersion, Value: hex.EncodeToString(sum[:])}
+}
@@ -0,0 +1,180 @@
+package apikey
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var scannerPattern = regexp.MustCompile(`\bcmk_(live|test)_[0-9A-Za-z]{38}\b`)
+
+func TestGenerate(t *testing.T) {
+	for _, env := range []string{EnvLive, EnvTest} {
+		key, err := Generate(env)
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		if !scannerPattern.MatchString(key) {
+			t.Errorf("expected %s to match the scanner pattern", key)
+		}
+		if got, err := Environment(key); err != nil || got != env {
+			t.Errorf("expected environment %s, got %q (%v)", env, got, err)
+		}
+	}
+
+	a, _ := Generate(EnvLive)
+	b, _ := Generate(EnvLive)
+	if a == b {
+		t.Errorf("expected distinct keys, got %s twice", a)
+	}
+	if _, err := Generate("prod"); err == nil {
+		t.Errorf("expected an error for an unknown environment")
+	}
+}
+
+func TestGenerate_SkipsBiasedBytes(t *testing.T) {
+	// Bytes from 248 up are dropped, so only the second half is used
+	random := bytes.NewReader(append(bytes.Repeat([]byte{250}, 64), bytes.Repeat([]byte{63}, 64)...))
+	key, err := generate(EnvTest, random)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if want := "cmk_test_" + strings.Repeat("1", secretLength); !strings.HasPrefix(key, want) {
+		t.Errorf("expected %s..., got %s", want, key)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	seq := make([]byte, 64)
+	for i := range seq {
+		seq[i] = byte(i)
+	}
+	key, _ := generate(EnvLive, bytes.NewReader(seq))
+	last := key[len(key)-1]
+	flipped := byte('A')
+	if last == 'A' {
+		flipped = 'B'
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		err  error
+	}{
+		{"valid", key, nil},
+		{"typo", key[:12] + string(key[13]) + string(key[12]) + key[14:], ErrMalformed},
+		{"wrong checksum", key[:len(key)-1] + string(flipped), ErrMalformed},
+		{"truncated", key[:len(key)-1], ErrMalformed},
+		{"other environment", strings.Replace(key, "_live_", "_test_", 1), ErrMalformed},
+		{"unknown environment", strings.Replace(key, "_live_", "_prod_", 1), ErrMalformed},
+		{"bad character", key[:10] + "-" + key[11:], ErrMalformed},
+		{"older format", "3f9a0c1e5b7d42a8", ErrUnknownFormat},
+		{"empty", "", ErrUnknownFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.key); !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestParsePeppers(t *testing.T) {
+	peppers, err := ParsePeppers(" 2:new-secret , 1:old:secret,")
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(peppers) != 2 || peppers[0].Version != 2 || string(peppers[1].Secret) != "old:secret" {
+		t.Errorf("unexpected peppers %+v", peppers)
+	}
+
+	for _, in := range []string{"secret", "v1:secret"} {
+		if _, err := ParsePeppers(in); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+}
+
+var (
+	oldPepper = Pepper{Version: 1, Secret: []byte(strings.Repeat("o", MinPepperLength))}
+	newPepper = Pepper{Version: 2, Secret: []byte(strings.Repeat("n", MinPepperLength))}
+)
+
+func TestHasher(t *testing.T) {
+	h, err := NewHasher([]Pepper{oldPepper, newPepper}, true)
+	if err != nil {
+		t.Fatalf("new hasher failed: %v", err)
+	}
+
+	hash := h.Hash("cmk_live_key")
+	if hash.Version != 2 || len(hash.Value) != 64 {
+		t.Errorf("expected a 64 character hash under version 2, got %+v", hash)
+	}
+	if hash.Value == legacyHash("cmk_live_key").Value {
+		t.Errorf("expected the hash to be keyed")
+	}
+
+	candidates := h.Candidates("cmk_live_key")
+	var versions []int
+	for _, c := range candidates {
+		versions = append(versions, c.Version)
+	}
+	if len(versions) != 3 || versions[0] != 2 || versions[1] != 1 || versions[2] != LegacyVersion {
+		t.Errorf("expected versions [2 1 0], got %v", versions)
+	}
+	if candidates[0] != hash {
+		t.Errorf("expected the current hash first, got %+v", candidates[0])
+	}
+}
+
+func TestHasher_Rotate(t *testing.T) {
+	h, _ := NewHasher([]Pepper{oldPepper}, false)
+	before := h.Hash("key")
+
+	if err := h.Rotate([]Pepper{newPepper, oldPepper}, false); err != nil {
+		t.Fatalf("rotate failed: %v", err)
+	}
+	if h.Hash("key") == before {
+		t.Errorf("expected new hashes under the new pepper")
+	}
+	if c := h.Candidates("key"); len(c) != 2 || c[1] != before {
+		t.Errorf("expected the old hash to stay a candidate, got %+v", c)
+	}
+
+	tests := []struct {
+		name    string
+		peppers []Pepper
+		legacy  bool
+	}{
+		{"none", nil, false},
+		{"duplicate version", []Pepper{oldPepper, {Version: 1, Secret: newPepper.Secret}}, false},
+		{"legacy version", []Pepper{{Version: LegacyVersion, Secret: newPepper.Secret}}, true},
+		{"short secret", []Pepper{{Version: 3, Secret: []byte("short")}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := h.Rotate(tt.peppers, tt.legacy); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+	if h.Hash("key").Version != 2 {
+		t.Errorf("expected failed rotations to leave the hasher unchanged")
+	}
+}
+
+func TestHasher_LegacyOnly(t *testing.T) {
+	h, err := NewHasher(nil, true)
+	if err != nil {
+		t.Fatalf("new hasher failed: %v", err)
+	}
+	// Matches the unkeyed hashes stored before peppers
+	want := "2c70e12b7a0646f92279f427c7b38e7334d8e5389cff167a1dc30e73f826b683"
+	if got := h.Hash("key"); got.Version != LegacyVersion || got.Value != want {
+		t.Errorf("expected %s, got %+v", want, got)
+	}
+}
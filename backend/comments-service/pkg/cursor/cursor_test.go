@@ -0,0 +1,104 @@
+package cursor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner_RoundTrip(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	in := Cursor{
+		Order:     "new",
+		Pinned:    true,
+		Score:     42,
+		CreatedAt: time.Date(2025, 2, 16, 12, 0, 0, 123456000, time.UTC),
+		ID:        "6f1c2a4e-3b7d-4c1e-9a2b-8d0e5f6a7b8c",
+	}
+
+	token, err := s.Encode(in)
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+
+	var out Cursor
+	if err := s.Decode(token, &out); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("expected %+v, got %+v", in, out)
+	}
+}
+
+func TestSigner_RejectsTampering(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	token, _ := s.Encode(Cursor{Order: "new", ID: "a"})
+	forged, _ := NewSigner([]byte("other")).Encode(Cursor{Order: "new", ID: "a"})
+	body, tag, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no tag", body},
+		{"modified body", body[:len(body)-2] + "xx." + tag},
+		{"wrong key", forged},
+		{"garbage", "%%%.%%%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Cursor
+			if err := s.Decode(tt.token, &c); !errors.Is(err, ErrInvalid) {
+				t.Errorf("expected ErrInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSigner_Rotate(t *testing.T) {
+	s := NewSigner([]byte("first"))
+	old, _ := s.Encode(Cursor{Order: "new", ID: "a"})
+
+	s.Rotate([]byte("second"))
+	current, _ := s.Encode(Cursor{Order: "new", ID: "b"})
+
+	var c Cursor
+	if err := s.Decode(old, &c); err != nil || c.ID != "a" {
+		t.Errorf("expected token from the previous key to decode, got %v", err)
+	}
+	if err := NewSigner([]byte("second")).Decode(current, &c); err != nil {
+		t.Errorf("expected new tokens to use the new key, got %v", err)
+	}
+
+	s.Rotate([]byte("third"))
+	if err := s.Decode(old, &c); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected token two rotations old to be rejected, got %v", err)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		in, want int
+	}{
+		{0, DefaultLimit},
+		{-5, DefaultLimit},
+		{50, 50},
+		{500, MaxLimit},
+	}
+
+	for _, tt := range tests {
+		if got := Limit(tt.in); got != tt.want {
+			t.Errorf("Limit(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCursor_Reverse(t *testing.T) {
+	c := Cursor{ID: "a"}
+	if !c.Reverse().Backward || c.Reverse().Reverse().Backward {
+		t.Error("Reverse should toggle direction")
+	}
+}
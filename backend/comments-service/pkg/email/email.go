@@ -0,0 +1,357 @@
+// Package email validates email addresses: the addr-spec syntax of
+// RFC 5322 including quoted local parts, UTF-8 local parts (RFC 6531),
+// internationalized domains converted to punycode, and per-tenant domain
+// policies. Failures are *Error values whose Reason says what was wrong in
+// a form clients can act on.
+package email
+
+import (
+	"bufio"
+	_ "embed"
+	stderrors "errors"
+	"net/netip"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+)
+
+// Length limits of RFC 5321
+const (
+	MaxLocalLength  = 64
+	MaxDomainLength = 253
+	MaxLength       = 254
+	maxLabelLength  = 63
+)
+
+// Reason says why an address was rejected
+type Reason string
+
+const (
+	ReasonEmpty            Reason = "empty"
+	ReasonSyntax           Reason = "syntax"
+	ReasonLocalPart        Reason = "local_part"
+	ReasonDomain           Reason = "domain"
+	ReasonTooLong          Reason = "too_long"
+	ReasonDomainDenied     Reason = "domain_denied"
+	ReasonDomainNotAllowed Reason = "domain_not_allowed"
+	ReasonDisposable       Reason = "disposable"
+)
+
+var messages = map[Reason]string{
+	ReasonEmpty:            "is required",
+	ReasonSyntax:           "must be an email address",
+	ReasonLocalPart:        "has an invalid part before the @",
+	ReasonDomain:           "has an invalid domain",
+	ReasonTooLong:          "is too long",
+	ReasonDomainDenied:     "uses a domain that is not accepted",
+	ReasonDomainNotAllowed: "uses a domain that is not on the allowed list",
+	ReasonDisposable:       "uses a disposable email service",
+}
+
+// Error is a rejected address
+type Error struct {
+	Reason  Reason
+	Address string
+}
+
+func (e *Error) Error() string {
+	return "email: " + messages[e.Reason]
+}
+
+// Rule returns the validation rule the reason is reported under: "email"
+// for malformed addresses, and a rule of its own for each policy
+func (e *Error) Rule() string {
+	switch e.Reason {
+	case ReasonDomainDenied, ReasonDomainNotAllowed, ReasonDisposable:
+		return "email_" + string(e.Reason)
+	}
+	return "email"
+}
+
+// FieldError describes the rejection as a violation of the field at path
+func (e *Error) FieldError(path string) errors.FieldError {
+	return errors.FieldError{
+		Path:    path,
+		Rule:    e.Rule(),
+		Params:  map[string]any{"reason": string(e.Reason)},
+		Message: messages[e.Reason],
+	}
+}
+
+// ReasonOf returns the reason err rejected an address, or "" when err is
+// not an *Error
+func ReasonOf(err error) Reason {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e.Reason
+	}
+	return ""
+}
+
+// Address is a parsed address
+type Address struct {
+	// Local is the part before the @, quotes included when it is quoted
+	Local string
+	// Domain is the lowercase ASCII domain, punycode for internationalized
+	// names, or a bracketed address literal
+	Domain string
+}
+
+// String returns the address with its ASCII domain
+func (a *Address) String() string {
+	return a.Local + "@" + a.Domain
+}
+
+// UnicodeDomain returns the domain as users write it
+func (a *Address) UnicodeDomain() string {
+	if u, err := idna.Lookup.ToUnicode(a.Domain); err == nil {
+		return u
+	}
+	return a.Domain
+}
+
+// Parse checks the syntax of s, an addr-spec without display name or
+// comments
+func Parse(s string) (*Address, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, &Error{Reason: ReasonEmpty, Address: s}
+	}
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 || at == len(s)-1 {
+		return nil, &Error{Reason: ReasonSyntax, Address: s}
+	}
+	local, domain := s[:at], s[at+1:]
+
+	if !validLocal(local) {
+		return nil, &Error{Reason: ReasonLocalPart, Address: s}
+	}
+	if len(local) > MaxLocalLength {
+		return nil, &Error{Reason: ReasonTooLong, Address: s}
+	}
+	ascii, reason := normalizeDomain(domain)
+	if reason != "" {
+		return nil, &Error{Reason: reason, Address: s}
+	}
+	if len(local)+1+len(ascii) > MaxLength {
+		return nil, &Error{Reason: ReasonTooLong, Address: s}
+	}
+	return &Address{Local: local, Domain: ascii}, nil
+}
+
+// NormalizeDomain returns the lowercase ASCII form of a domain name, for
+// comparing against addresses
+func NormalizeDomain(domain string) (string, error) {
+	ascii, reason := normalizeDomain(domain)
+	if reason != "" || strings.HasPrefix(ascii, "[") {
+		return "", &Error{Reason: ReasonDomain, Address: domain}
+	}
+	return ascii, nil
+}
+
+func validLocal(local string) bool {
+	if strings.HasPrefix(local, `"`) {
+		return validQuoted(local)
+	}
+	// dot-atom: atoms separated by single dots
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			if !isAtext(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validQuoted checks a quoted-string: printable characters and spaces,
+// with backslash escapes, between double quotes
+func validQuoted(local string) bool {
+	if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+		return false
+	}
+	inner := local[1 : len(local)-1]
+	for i := 0; i < len(inner); {
+		r, n := utf8.DecodeRuneInString(inner[i:])
+		i += n
+		switch {
+		case r == '\\':
+			if i == len(inner) {
+				return false
+			}
+			next, n := utf8.DecodeRuneInString(inner[i:])
+			if next != ' ' && !isVisibleASCII(next) {
+				return false
+			}
+			i += n
+		case r == '"':
+			return false
+		case r == ' ', isVisibleASCII(r), isUTF8Char(r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isAtext(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r):
+		return true
+	}
+	return isUTF8Char(r)
+}
+
+func isVisibleASCII(r rune) bool {
+	return r >= '!' && r <= '~'
+}
+
+// isUTF8Char accepts the non-ASCII characters RFC 6531 allows, leaving
+// out invisible format characters
+func isUTF8Char(r rune) bool {
+	return r >= utf8.RuneSelf && r != utf8.RuneError && unicode.IsGraphic(r) && !unicode.Is(unicode.Cf, r)
+}
+
+// normalizeDomain converts domain to lowercase ASCII, or returns why it is
+// invalid
+func normalizeDomain(domain string) (string, Reason) {
+	if strings.HasPrefix(domain, "[") {
+		return normalizeLiteral(domain)
+	}
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", ReasonDomain
+	}
+	ascii = strings.ToLower(ascii)
+	if len(ascii) > MaxDomainLength {
+		return "", ReasonTooLong
+	}
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 {
+		return "", ReasonDomain
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLength {
+			return "", ReasonDomain
+		}
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return "", ReasonDomain
+	}
+	return ascii, ""
+}
+
+// normalizeLiteral checks an address literal, [192.0.2.1] or
+// [IPv6:2001:db8::1]
+func normalizeLiteral(domain string) (string, Reason) {
+	if !strings.HasSuffix(domain, "]") {
+		return "", ReasonDomain
+	}
+	inner := domain[1 : len(domain)-1]
+	if v6, ok := strings.CutPrefix(inner, "IPv6:"); ok {
+		addr, err := netip.ParseAddr(v6)
+		if err != nil || !addr.Is6() {
+			return "", ReasonDomain
+		}
+		return "[IPv6:" + addr.String() + "]", ""
+	}
+	addr, err := netip.ParseAddr(inner)
+	if err != nil || !addr.Is4() {
+		return "", ReasonDomain
+	}
+	return "[" + addr.String() + "]", ""
+}
+
+// Policy restricts which domains a tenant accepts addresses from
+type Policy struct {
+	// AllowedDomains, when not empty, is the only domains accepted
+	AllowedDomains []string
+	// DeniedDomains are rejected, even when allowed
+	DeniedDomains []string
+	// BlockDisposable rejects domains of disposable email services
+	BlockDisposable bool
+}
+
+// Check applies p to a parsed address. Listed domains match their
+// subdomains too.
+func (p Policy) Check(a *Address) error {
+	if matchesAny(a.Domain, p.DeniedDomains) {
+		return &Error{Reason: ReasonDomainDenied, Address: a.String()}
+	}
+	if len(p.AllowedDomains) > 0 && !matchesAny(a.Domain, p.AllowedDomains) {
+		return &Error{Reason: ReasonDomainNotAllowed, Address: a.String()}
+	}
+	if p.BlockDisposable && IsDisposable(a.Domain) {
+		return &Error{Reason: ReasonDisposable, Address: a.String()}
+	}
+	return nil
+}
+
+// Validate parses s and applies p
+func Validate(s string, p Policy) (*Address, error) {
+	a, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Check(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func matchesAny(domain string, list []string) bool {
+	for _, entry := range list {
+		entry, err := NormalizeDomain(entry)
+		if err != nil {
+			continue
+		}
+		if domain == entry || strings.HasSuffix(domain, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+//go:embed disposable_domains.txt
+var disposableList string
+
+var (
+	disposableOnce    sync.Once
+	disposableDomains map[string]struct{}
+)
+
+// IsDisposable reports whether domain, or a domain it belongs to, is a
+// known disposable email service
+func IsDisposable(domain string) bool {
+	disposableOnce.Do(func() {
+		disposableDomains = make(map[string]struct{})
+		scanner := bufio.NewScanner(strings.NewReader(disposableList))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				disposableDomains[line] = struct{}{}
+			}
+		}
+	})
+
+	domain = strings.ToLower(domain)
+	for {
+		if _, ok := disposableDomains[domain]; ok {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return false
+		}
+		domain = parent
+	}
+}
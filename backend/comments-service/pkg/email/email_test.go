@@ -0,0 +1,110 @@
+package email
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		want   string
+		reason Reason
+	}{
+		{name: "simple", in: "user@example.com", want: "user@example.com"},
+		{name: "subdomain", in: "user@mail.example.com", want: "user@mail.example.com"},
+		{name: "plus and symbols", in: "first.last+tag!#$%&'*/=?^_`{|}~-@example.org", want: "first.last+tag!#$%&'*/=?^_`{|}~-@example.org"},
+		{name: "domain is lowercased", in: "User@Example.COM", want: "User@example.com"},
+		{name: "quoted local part", in: `"john doe"@example.com`, want: `"john doe"@example.com`},
+		{name: "quoted at and escapes", in: `"a@b\"c"@example.com`, want: `"a@b\"c"@example.com`},
+		{name: "idn domain", in: "user@bücher.de", want: "user@xn--bcher-kva.de"},
+		{name: "punycode domain", in: "user@xn--bcher-kva.de", want: "user@xn--bcher-kva.de"},
+		{name: "utf8 local part", in: "josé@example.com", want: "josé@example.com"},
+		{name: "ipv4 literal", in: "user@[192.0.2.1]", want: "user@[192.0.2.1]"},
+		{name: "ipv6 literal", in: "user@[IPv6:2001:DB8::1]", want: "user@[IPv6:2001:db8::1]"},
+
+		{name: "empty", in: " ", reason: ReasonEmpty},
+		{name: "no at", in: "userexample.com", reason: ReasonSyntax},
+		{name: "no local part", in: "@example.com", reason: ReasonSyntax},
+		{name: "no domain", in: "user@", reason: ReasonSyntax},
+		{name: "double at", in: "user@@example.com", reason: ReasonLocalPart},
+		{name: "leading dot", in: ".user@example.com", reason: ReasonLocalPart},
+		{name: "double dot", in: "us..er@example.com", reason: ReasonLocalPart},
+		{name: "space", in: "john doe@example.com", reason: ReasonLocalPart},
+		{name: "unterminated quote", in: `"john@example.com`, reason: ReasonLocalPart},
+		{name: "zero-width in local part", in: "us\u200Ber@example.com", reason: ReasonLocalPart},
+		{name: "no tld", in: "user@example", reason: ReasonDomain},
+		{name: "numeric tld", in: "user@example.123", reason: ReasonDomain},
+		{name: "empty label", in: "user@example..com", reason: ReasonDomain},
+		{name: "trailing dot", in: "user@example.com.", reason: ReasonDomain},
+		{name: "underscore in domain", in: "user@exa_mple.com", reason: ReasonDomain},
+		{name: "leading hyphen", in: "user@-example.com", reason: ReasonDomain},
+		{name: "bad literal", in: "user@[300.1.1.1]", reason: ReasonDomain},
+		{name: "long label", in: "user@" + strings.Repeat("a", 64) + ".com", reason: ReasonDomain},
+		{name: "long local part", in: strings.Repeat("a", 65) + "@example.com", reason: ReasonTooLong},
+		{name: "long address", in: strings.Repeat("a", 64) + "@" + strings.Repeat(strings.Repeat("b", 60)+".", 4) + "com", reason: ReasonTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.in)
+			if tt.reason != "" {
+				if got := ReasonOf(err); got != tt.reason {
+					t.Errorf("expected reason %s, got %q (%v)", tt.reason, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if a.String() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, a)
+			}
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		address string
+		reason  Reason
+	}{
+		{name: "no policy", address: "user@mailinator.com"},
+		{name: "denied", policy: Policy{DeniedDomains: []string{"spam.example"}}, address: "user@spam.example", reason: ReasonDomainDenied},
+		{name: "denied subdomain", policy: Policy{DeniedDomains: []string{"spam.example"}}, address: "user@mx.SPAM.example", reason: ReasonDomainDenied},
+		{name: "similar name is not a subdomain", policy: Policy{DeniedDomains: []string{"spam.example"}}, address: "user@notspam.example"},
+		{name: "allowed", policy: Policy{AllowedDomains: []string{"corp.example"}}, address: "user@corp.example"},
+		{name: "not allowed", policy: Policy{AllowedDomains: []string{"corp.example"}}, address: "user@gmail.com", reason: ReasonDomainNotAllowed},
+		{name: "deny wins over allow", policy: Policy{AllowedDomains: []string{"corp.example"}, DeniedDomains: []string{"old.corp.example"}}, address: "user@old.corp.example", reason: ReasonDomainDenied},
+		{name: "idn list entry", policy: Policy{AllowedDomains: []string{"BÜCHER.de"}}, address: "user@xn--bcher-kva.de"},
+		{name: "disposable", policy: Policy{BlockDisposable: true}, address: "user@mailinator.com", reason: ReasonDisposable},
+		{name: "disposable subdomain", policy: Policy{BlockDisposable: true}, address: "user@x.yopmail.com", reason: ReasonDisposable},
+		{name: "regular provider", policy: Policy{BlockDisposable: true}, address: "user@example.com"},
+		{name: "syntax first", policy: Policy{BlockDisposable: true}, address: "user@mailinator", reason: ReasonDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Validate(tt.address, tt.policy)
+			if got := ReasonOf(err); got != tt.reason {
+				t.Errorf("expected reason %q, got %q (%v)", tt.reason, got, err)
+			}
+		})
+	}
+}
+
+func TestError_FieldError(t *testing.T) {
+	_, err := Validate("user@mailinator.com", Policy{BlockDisposable: true})
+	fe := err.(*Error).FieldError("author_email")
+	if fe.Path != "author_email" || fe.Rule != "email_disposable" || fe.Params["reason"] != "disposable" {
+		t.Errorf("unexpected field error %+v", fe)
+	}
+
+	_, err = Parse("nope")
+	if fe := err.(*Error).FieldError("email"); fe.Rule != "email" || fe.Params["reason"] != "syntax" {
+		t.Errorf("unexpected field error %+v", fe)
+	}
+}
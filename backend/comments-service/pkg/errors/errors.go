@@ -1,15 +1,57 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"runtime"
+	"strings"
+	"time"
 )
 
 type AppError struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
 	StatusCode int    `json:"status_code"`
-	Err        error  `json:"-"`
+	// Errors lists the fields that failed, for validation errors
+	Errors []FieldError `json:"errors,omitempty"`
+	Err    error        `json:"-"`
+	// RateLimit is the quota a rate-limited request was counted against
+	RateLimit *RateLimit `json:"-"`
+	// MessageID names Message in the i18n catalog and Args fills it in, so
+	// it can be rendered in the reader's language
+	MessageID string         `json:"-"`
+	Args      map[string]any `json:"-"`
+
+	// stack is where an internal error was created, for the logs
+	stack []uintptr
+	// retryable marks failures the same request may succeed after
+	retryable bool
+	// retryAfter is how long the client should wait before retrying
+	retryAfter time.Duration
+}
+
+// RateLimit describes a quota: Limit requests per window, Remaining of
+// them left, and the window starting over at Reset
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// FieldError is a violation of one rule by one field of a request. Path
+// names the field as the client sent it, as in settings.max_nesting_depth;
+// Rule is machine-readable and Params holds the rule's arguments, such as
+// {"max": 255} for max.
+type FieldError struct {
+	Path    string         `json:"path"`
+	Rule    string         `json:"rule"`
+	Params  map[string]any `json:"params,omitempty"`
+	Message string         `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Path + " " + e.Message
 }
 
 func (e *AppError) Error() string {
@@ -23,6 +65,75 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// Stack formats the call stack recorded when e was created, one
+// "function file:line" per line. Only internal errors record one.
+func (e *AppError) Stack() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&b, "%s %s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// Retryable reports whether sending the same request again may succeed
+func (e *AppError) Retryable() bool {
+	return e.retryable
+}
+
+// RetryAfter is how long to wait before retrying: the hint set with
+// WithRetryAfter, else the time until a rate limit resets. Zero means no
+// hint.
+func (e *AppError) RetryAfter() time.Duration {
+	if e.retryAfter > 0 {
+		return e.retryAfter
+	}
+	if e.RateLimit != nil {
+		return max(time.Until(e.RateLimit.Reset), 0)
+	}
+	return 0
+}
+
+// WithRetryAfter sets the RetryAfter hint and returns e
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	e.retryAfter = d
+	return e
+}
+
+// WithMessageID sets the catalog ID of e's message and returns e
+func (e *AppError) WithMessageID(id string, args map[string]any) *AppError {
+	e.MessageID = id
+	e.Args = args
+	return e
+}
+
+// Is reports whether err is, or wraps, an AppError with code
+func Is(err error, code string) bool {
+	var appErr *AppError
+	return errors.As(err, &appErr) && appErr.Code == code
+}
+
+// IsRetryable reports whether err is, or wraps, an AppError that may
+// succeed if the same request is sent again
+func IsRetryable(err error) bool {
+	var appErr *AppError
+	return errors.As(err, &appErr) && appErr.Retryable()
+}
+
+// callers records the stack of the constructor's caller
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
 const (
 	ErrCodeNotFound          = "NOT_FOUND"
 	ErrCodeBadRequest        = "BAD_REQUEST"
@@ -32,6 +143,10 @@ const (
 	ErrCodeInternalServer    = "INTERNAL_SERVER_ERROR"
 	ErrCodeValidation        = "VALIDATION_ERROR"
 	ErrCodeRateLimitExceeded = "RATE_LIMIT_EXCEEDED"
+	ErrCodePayloadTooLarge   = "PAYLOAD_TOO_LARGE"
+	ErrCodeAborted           = "ABORTED"
+	ErrCodeUnavailable       = "SERVICE_UNAVAILABLE"
+	ErrCodeTimeout           = "TIMEOUT"
 )
 
 func NotFound(message string) *AppError {
@@ -58,12 +173,21 @@ func Unauthorized(message string) *AppError {
 	}
 }
 
+func Forbidden(message string) *AppError {
+	return &AppError{
+		Code:       ErrCodeForbidden,
+		Message:    message,
+		StatusCode: http.StatusForbidden,
+	}
+}
+
 func InternalServer(message string, err error) *AppError {
 	return &AppError{
 		Code:       ErrCodeInternalServer,
 		Message:    message,
 		StatusCode: http.StatusInternalServerError,
 		Err:        err,
+		stack:      callers(),
 	}
 }
 
@@ -73,4 +197,75 @@ func Conflict(message string) *AppError {
 		Message:    message,
 		StatusCode: http.StatusConflict,
 	}
-}
\ No newline at end of file
+}
+
+func PayloadTooLarge(message string) *AppError {
+	return &AppError{
+		Code:       ErrCodePayloadTooLarge,
+		Message:    message,
+		StatusCode: http.StatusRequestEntityTooLarge,
+	}
+}
+
+// Aborted reports a request that lost a race with a concurrent one, such as
+// a serialization failure or deadlock, and is safe to retry
+func Aborted(message string, err error) *AppError {
+	return &AppError{
+		Code:       ErrCodeAborted,
+		Message:    message,
+		StatusCode: http.StatusConflict,
+		Err:        err,
+		retryable:  true,
+	}
+}
+
+// RateLimited reports a request over its quota. It is retryable once the
+// quota resets.
+func RateLimited(message string, limit RateLimit) *AppError {
+	return &AppError{
+		Code:       ErrCodeRateLimitExceeded,
+		Message:    message,
+		StatusCode: http.StatusTooManyRequests,
+		RateLimit:  &limit,
+		retryable:  true,
+	}
+}
+
+// Unavailable reports that a dependency, such as the database, cannot be
+// reached. It is retryable.
+func Unavailable(message string, err error) *AppError {
+	return &AppError{
+		Code:       ErrCodeUnavailable,
+		Message:    message,
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        err,
+		retryable:  true,
+	}
+}
+
+// Timeout reports that a request ran out of time waiting on a dependency.
+// It is retryable.
+func Timeout(message string, err error) *AppError {
+	return &AppError{
+		Code:       ErrCodeTimeout,
+		Message:    message,
+		StatusCode: http.StatusGatewayTimeout,
+		Err:        err,
+		retryable:  true,
+	}
+}
+
+// Validation reports the fields of a request that failed validation
+func Validation(violations ...FieldError) *AppError {
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.Error()
+	}
+	return &AppError{
+		Code:       ErrCodeValidation,
+		Message:    "validation failed: " + strings.Join(msgs, "; "),
+		StatusCode: http.StatusUnprocessableEntity,
+		Errors:     violations,
+		MessageID:  "error.validation",
+	}
+}
@@ -2,17 +2,20 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNotFound(t *testing.T) {
 	err := NotFound("resource not found")
-	
+
 	if err.Code != ErrCodeNotFound {
 		t.Errorf("expected code %s, got %s", ErrCodeNotFound, err.Code)
 	}
-	
+
 	if err.StatusCode != http.StatusNotFound {
 		t.Errorf("expected status %d, got %d", http.StatusNotFound, err.StatusCode)
 	}
@@ -20,7 +23,7 @@ func TestNotFound(t *testing.T) {
 
 func TestAppError_Error(t *testing.T) {
 	err := InternalServer("database error", errors.New("connection failed"))
-	
+
 	expected := "database error: connection failed"
 	if err.Error() != expected {
 		t.Errorf("expected %s, got %s", expected, err.Error())
@@ -30,8 +33,93 @@ func TestAppError_Error(t *testing.T) {
 func TestAppError_Unwrap(t *testing.T) {
 	underlying := errors.New("underlying")
 	err := InternalServer("wrapped", underlying)
-	
+
 	if err.Unwrap() != underlying {
 		t.Error("Unwrap failed")
 	}
-}
\ No newline at end of file
+}
+
+func TestAppError_Stack(t *testing.T) {
+	err := InternalServer("wrapped", errors.New("underlying"))
+	if !strings.Contains(err.Stack(), "TestAppError_Stack") {
+		t.Errorf("expected stack to start at the caller, got %s", err.Stack())
+	}
+	if NotFound("missing").Stack() != "" {
+		t.Error("expected no stack for client errors")
+	}
+}
+
+func TestAborted(t *testing.T) {
+	err := Aborted("concurrent update", errors.New("40001"))
+	if err.StatusCode != http.StatusConflict || !err.Retryable() {
+		t.Errorf("expected retryable 409, got %d retryable=%v", err.StatusCode, err.Retryable())
+	}
+	if Conflict("exists").Retryable() {
+		t.Error("expected conflicts not to be retryable")
+	}
+}
+
+func TestTaxonomy(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *AppError
+		code      string
+		status    int
+		retryable bool
+	}{
+		{"forbidden", Forbidden("admin only"), ErrCodeForbidden, http.StatusForbidden, false},
+		{"rate limited", RateLimited("slow down", RateLimit{Limit: 10}), ErrCodeRateLimitExceeded, http.StatusTooManyRequests, true},
+		{"unavailable", Unavailable("database unavailable", nil), ErrCodeUnavailable, http.StatusServiceUnavailable, true},
+		{"timeout", Timeout("database timed out", nil), ErrCodeTimeout, http.StatusGatewayTimeout, true},
+		{"bad request", BadRequest("bad"), ErrCodeBadRequest, http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Code != tt.code || tt.err.StatusCode != tt.status {
+				t.Errorf("expected %d %s, got %d %s", tt.status, tt.code, tt.err.StatusCode, tt.err.Code)
+			}
+			wrapped := fmt.Errorf("handler: %w", tt.err)
+			if !Is(wrapped, tt.code) {
+				t.Errorf("expected Is(%s) through a wrap", tt.code)
+			}
+			if IsRetryable(wrapped) != tt.retryable {
+				t.Errorf("expected IsRetryable %v, got %v", tt.retryable, IsRetryable(wrapped))
+			}
+		})
+	}
+
+	if Is(errors.New("plain"), ErrCodeInternalServer) || IsRetryable(errors.New("plain")) {
+		t.Error("expected plain errors to match nothing")
+	}
+}
+
+func TestAppError_RetryAfter(t *testing.T) {
+	limited := RateLimited("slow down", RateLimit{Limit: 10, Reset: time.Now().Add(time.Minute)})
+	if d := limited.RetryAfter(); d <= 59*time.Second || d > time.Minute {
+		t.Errorf("expected about a minute until the reset, got %s", d)
+	}
+	if d := limited.WithRetryAfter(5 * time.Second).RetryAfter(); d != 5*time.Second {
+		t.Errorf("expected the explicit hint to win, got %s", d)
+	}
+	expired := RateLimited("slow down", RateLimit{Reset: time.Now().Add(-time.Minute)})
+	if d := expired.RetryAfter(); d != 0 {
+		t.Errorf("expected no wait after the reset, got %s", d)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	err := Validation(
+		FieldError{Path: "content", Rule: "required", Message: "is required"},
+		FieldError{Path: "author_email", Rule: "email", Message: "must be an email address"},
+	)
+	if err.Code != ErrCodeValidation || err.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 %s, got %d %s", ErrCodeValidation, err.StatusCode, err.Code)
+	}
+	if len(err.Errors) != 2 {
+		t.Errorf("expected 2 violations, got %d", len(err.Errors))
+	}
+	if err.Message != "validation failed: content is required; author_email must be an email address" {
+		t.Errorf("unexpected message %q", err.Message)
+	}
+}
@@ -0,0 +1,276 @@
+// Package i18n renders user-facing messages in the reader's language and
+// timezone. Messages are text/template strings keyed by error code
+// ("errors.NOT_FOUND") or template ID ("comment.not_found"), loaded from one
+// JSON file per language.
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	// Timezones from user preferences must resolve without system zoneinfo
+	_ "time/tzdata"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is the language of the source strings in code. Messages
+// fall back to it when a translation is missing.
+var DefaultLanguage = language.English
+
+// DateTimeKey is the message holding a language's time.Format layout
+const DateTimeKey = "format.datetime"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Catalog holds the parsed messages of every language
+type Catalog struct {
+	messages map[language.Tag]map[string]*template.Template
+	// layouts are the DateTimeKey messages, used as time.Format layouts
+	layouts map[language.Tag]string
+	matcher language.Matcher
+	tags    []language.Tag
+}
+
+var funcs = template.FuncMap{
+	"join": func(values any, sep string) string {
+		switch v := values.(type) {
+		case []string:
+			return strings.Join(v, sep)
+		case []any:
+			parts := make([]string, len(v))
+			for i, p := range v {
+				parts[i] = fmt.Sprint(p)
+			}
+			return strings.Join(parts, sep)
+		}
+		return fmt.Sprint(values)
+	},
+}
+
+// Load parses every <language>.json file in fsys. The default language must
+// be among them.
+func Load(fsys fs.FS) (*Catalog, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	c := &Catalog{
+		messages: map[language.Tag]map[string]*template.Template{},
+		layouts:  map[language.Tag]string{},
+	}
+	for _, file := range files {
+		tag, err := language.Parse(strings.TrimSuffix(path.Base(file), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		raw, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var strs map[string]string
+		if err := json.Unmarshal(raw, &strs); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		messages := make(map[string]*template.Template, len(strs))
+		for id, text := range strs {
+			t, err := template.New(id).Funcs(funcs).Option("missingkey=zero").Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", file, id, err)
+			}
+			messages[id] = t
+		}
+		c.messages[tag] = messages
+		if layout, ok := strs[DateTimeKey]; ok {
+			c.layouts[tag] = layout
+		}
+		c.tags = append(c.tags, tag)
+	}
+	if _, ok := c.messages[DefaultLanguage]; !ok {
+		return nil, fmt.Errorf("no messages for the default language %s", DefaultLanguage)
+	}
+	// The matcher prefers its first tag when nothing matches
+	for i, tag := range c.tags {
+		if tag == DefaultLanguage {
+			c.tags[0], c.tags[i] = c.tags[i], c.tags[0]
+		}
+	}
+	c.matcher = language.NewMatcher(c.tags)
+	return c, nil
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultCatalog *Catalog
+)
+
+// Default returns the catalog built from the embedded locales
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		sub, err := fs.Sub(locales, "locales")
+		if err == nil {
+			defaultCatalog, err = Load(sub)
+		}
+		if err != nil {
+			panic("i18n: embedded locales: " + err.Error())
+		}
+	})
+	return defaultCatalog
+}
+
+// Languages lists the languages with messages, the default first
+func (c *Catalog) Languages() []language.Tag {
+	return append([]language.Tag(nil), c.tags...)
+}
+
+// Locale picks the language and timezone to render in. A supported
+// preferred language, such as the one in users.preferences, wins over the
+// Accept-Language header; timezone is an IANA name and defaults to UTC.
+func (c *Catalog) Locale(preferred, acceptLanguage, timezone string) Locale {
+	loc := Locale{Language: DefaultLanguage, Location: time.UTC, catalog: c}
+	if tz, err := time.LoadLocation(timezone); err == nil && timezone != "" {
+		loc.Location = tz
+	}
+
+	if preferred != "" {
+		if tag, err := language.Parse(preferred); err == nil {
+			if _, i, confidence := c.matcher.Match(tag); confidence >= language.High {
+				loc.Language = c.tags[i]
+				return loc
+			}
+		}
+	}
+	if acceptLanguage != "" {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil && len(tags) > 0 {
+			if _, i, confidence := c.matcher.Match(tags...); confidence > language.No {
+				loc.Language = c.tags[i]
+			}
+		}
+	}
+	return loc
+}
+
+// Locale renders messages for one reader
+type Locale struct {
+	Language language.Tag
+	Location *time.Location
+	catalog  *Catalog
+}
+
+// IsDefault reports whether l renders the source language, in which case
+// messages written in code need no translation
+func (l Locale) IsDefault() bool {
+	return l.Language == DefaultLanguage
+}
+
+// Message renders the message id with args in l's language. It reports
+// false when the language has no such message; callers keep their own text.
+// time.Time args are formatted in l's timezone.
+func (l Locale) Message(id string, args map[string]any) (string, bool) {
+	t, ok := l.cat().messages[l.Language][id]
+	if !ok {
+		return "", false
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, l.localize(args)); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// Render is Message falling back to the default language, for texts such
+// as emails that exist only in the catalog
+func (l Locale) Render(id string, args map[string]any) (string, error) {
+	if msg, ok := l.Message(id, args); ok {
+		return msg, nil
+	}
+	fallback := l
+	fallback.Language = DefaultLanguage
+	if msg, ok := fallback.Message(id, args); ok {
+		return msg, nil
+	}
+	return "", fmt.Errorf("i18n: no message %q", id)
+}
+
+// FormatTime formats t in l's timezone with the language's layout
+func (l Locale) FormatTime(t time.Time) string {
+	layout, ok := l.cat().layouts[l.Language]
+	if !ok {
+		layout = time.RFC1123
+	}
+	return t.In(l.location()).Format(layout)
+}
+
+// cat lets the zero Locale render from the default catalog
+func (l Locale) cat() *Catalog {
+	if l.catalog == nil {
+		return Default()
+	}
+	return l.catalog
+}
+
+func (l Locale) location() *time.Location {
+	if l.Location == nil {
+		return time.UTC
+	}
+	return l.Location
+}
+
+func (l Locale) localize(args map[string]any) map[string]any {
+	if len(args) == 0 {
+		return args
+	}
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		if t, ok := v.(time.Time); ok {
+			v = l.FormatTime(t)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+type contextKey struct{}
+
+// lazyLocale is resolved by the first FromContext that needs it
+type lazyLocale func() Locale
+
+// NewContext returns a context carrying loc
+func NewContext(ctx context.Context, loc Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, loc)
+}
+
+// NewLazyContext returns a context whose locale is chosen by resolve the
+// first time FromContext asks for it. This suits locales that depend on
+// what is only known later in the request, such as its tenant.
+func NewLazyContext(ctx context.Context, resolve func() Locale) context.Context {
+	var (
+		once sync.Once
+		loc  Locale
+	)
+	return context.WithValue(ctx, contextKey{}, lazyLocale(func() Locale {
+		once.Do(func() { loc = resolve() })
+		return loc
+	}))
+}
+
+// FromContext returns the locale stored in ctx, or the default language in
+// UTC
+func FromContext(ctx context.Context) Locale {
+	switch loc := ctx.Value(contextKey{}).(type) {
+	case Locale:
+		return loc
+	case lazyLocale:
+		return loc()
+	}
+	return Locale{Language: DefaultLanguage, Location: time.UTC, catalog: Default()}
+}
@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestDefault_LocalesAreComplete(t *testing.T) {
+	c := Default()
+	english := c.messages[DefaultLanguage]
+	for _, tag := range c.Languages() {
+		for id := range english {
+			if _, ok := c.messages[tag][id]; !ok {
+				t.Errorf("%s is missing %s", tag, id)
+			}
+		}
+		for id := range c.messages[tag] {
+			if _, ok := english[id]; !ok {
+				t.Errorf("%s has %s, which English does not", tag, id)
+			}
+		}
+	}
+	if got := c.Languages()[0]; got != DefaultLanguage {
+		t.Errorf("expected the default language first, got %s", got)
+	}
+}
+
+func TestCatalog_Locale(t *testing.T) {
+	c := Default()
+	tests := []struct {
+		name      string
+		preferred string
+		accept    string
+		want      language.Tag
+	}{
+		{name: "nothing", want: language.English},
+		{name: "accept language", accept: "de-DE,de;q=0.9,en;q=0.8", want: language.German},
+		{name: "accept language quality", accept: "en;q=0.5, fr;q=0.9", want: language.French},
+		{name: "unsupported accept language", accept: "ja", want: language.English},
+		{name: "preference wins", preferred: "es", accept: "fr", want: language.Spanish},
+		{name: "regional preference", preferred: "es-MX", want: language.Spanish},
+		{name: "unsupported preference", preferred: "ja", accept: "fr", want: language.French},
+		{name: "malformed", preferred: "%%", accept: ";;;", want: language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Locale(tt.preferred, tt.accept, "").Language; got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLocale_Message(t *testing.T) {
+	loc := Default().Locale("es", "", "")
+
+	msg, ok := loc.Message("rules.oneof", map[string]any{"values": []string{"new", "old", "top"}})
+	if !ok || msg != "debe ser uno de new, old, top" {
+		t.Errorf("unexpected message %q (%v)", msg, ok)
+	}
+	if _, ok := loc.Message("no.such.message", nil); ok {
+		t.Error("expected a missing message to report false")
+	}
+}
+
+func TestLocale_TimezoneAndFallback(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": {Data: []byte(`{"format.datetime": "2006-01-02 15:04 MST", "posted": "posted {{.at}}", "only.en": "hello"}`)},
+		"de.json": {Data: []byte(`{"format.datetime": "02.01.2006 15:04", "posted": "gepostet {{.at}}"}`)},
+	}
+	c, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	loc := c.Locale("de", "", "Europe/Berlin")
+	if msg, _ := loc.Message("posted", map[string]any{"at": at}); msg != "gepostet 01.03.2024 13:30" {
+		t.Errorf("expected German time in Berlin, got %q", msg)
+	}
+	if msg, err := loc.Render("only.en", nil); err != nil || msg != "hello" {
+		t.Errorf("expected the English fallback, got %q (%v)", msg, err)
+	}
+	if _, err := loc.Render("missing", nil); err == nil {
+		t.Error("expected an error for a message no language has")
+	}
+
+	if got := c.Locale("", "", "Not/AZone").Location; got != time.UTC {
+		t.Errorf("expected UTC for an unknown timezone, got %s", got)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fs.FS
+	}{
+		{"no default language", fstest.MapFS{"de.json": {Data: []byte(`{}`)}}},
+		{"bad json", fstest.MapFS{"en.json": {Data: []byte(`{`)}}},
+		{"bad template", fstest.MapFS{"en.json": {Data: []byte(`{"a": "{{.x"}`)}}},
+		{"bad language", fstest.MapFS{"en.json": {Data: []byte(`{}`)}, "not a tag.json": {Data: []byte(`{}`)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if loc := FromContext(context.Background()); !loc.IsDefault() {
+		t.Errorf("expected the default language, got %s", loc.Language)
+	}
+	ctx := NewContext(context.Background(), Default().Locale("fr", "", ""))
+	if loc := FromContext(ctx); loc.Language != language.French {
+		t.Errorf("expected French, got %s", loc.Language)
+	}
+}
+
+func TestNewLazyContext(t *testing.T) {
+	var calls int
+	ctx := NewLazyContext(context.Background(), func() Locale {
+		calls++
+		return Default().Locale("de", "", "")
+	})
+	if calls != 0 {
+		t.Errorf("expected no resolution before use, got %d", calls)
+	}
+	for i := 0; i < 2; i++ {
+		if loc := FromContext(ctx); loc.Language != language.German {
+			t.Errorf("expected German, got %s", loc.Language)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 resolution, got %d", calls)
+	}
+}
@@ -0,0 +1,325 @@
+// Package id generates time-ordered, collision-safe identifiers. An ID is
+// a UUIDv7 (RFC 9562): a 48-bit Unix millisecond timestamp, a 12-bit
+// counter that keeps IDs from one generator strictly increasing within a
+// millisecond, and 62 random bits. IDs sort by creation time in every
+// encoding, which keeps B-tree inserts on hot tables append-only.
+//
+// The canonical form is the hyphenated UUID stored in uuid columns. Base32
+// (Crockford, 26 characters, ULID-style) and Base62 (22 characters) are
+// shorter forms; a typed ID is a prefix such as "cmt" and the Base62 form,
+// as in cmt_0Gkh3lsZQJ8BmS4VMXPmd1.
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ID is a UUIDv7
+type ID [16]byte
+
+// Nil is the zero ID
+var Nil ID
+
+// Prefixes of typed IDs
+const (
+	PrefixComment = "cmt"
+	PrefixAPIKey  = "key"
+	PrefixTenant  = "tnt"
+	PrefixUser    = "usr"
+	PrefixLike    = "lik"
+	PrefixSession = "ses"
+)
+
+// Errors returned when parsing
+var (
+	ErrInvalid        = errors.New("id: invalid ID")
+	ErrPrefixMismatch = errors.New("id: unexpected prefix")
+)
+
+const (
+	version = 7
+	// counterBits is the width of rand_a, used as the counter
+	counterBits = 12
+	maxCounter  = 1<<counterBits - 1
+	// counterSeedMask leaves the counter's top bit clear when a new
+	// millisecond reseeds it, so at least 2048 IDs fit in one millisecond
+	// before the timestamp has to borrow from the next
+	counterSeedMask = maxCounter >> 1
+)
+
+// Generator hands out strictly increasing IDs. It is safe for concurrent
+// use.
+type Generator struct {
+	now    func() time.Time
+	random io.Reader
+
+	mu      sync.Mutex
+	lastMS  int64
+	counter uint16
+}
+
+// NewGenerator creates a generator reading the clock from now and entropy
+// from random; nil selects time.Now and crypto/rand
+func NewGenerator(now func() time.Time, random io.Reader) *Generator {
+	if now == nil {
+		now = time.Now
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+	return &Generator{now: now, random: random}
+}
+
+var defaultGenerator = NewGenerator(nil, nil)
+
+// New returns an ID from the default generator
+func New() (ID, error) {
+	return defaultGenerator.New()
+}
+
+// MustNew is New for callers that cannot handle a failing entropy source
+func MustNew() ID {
+	id, err := New()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// New returns an ID greater than every ID g returned before. The clock
+// moving backwards, or more than 4096 IDs in a millisecond, makes the
+// timestamp run slightly ahead of the clock instead of breaking the order.
+func (g *Generator) New() (ID, error) {
+	var id ID
+	if _, err := io.ReadFull(g.random, id[6:]); err != nil {
+		return Nil, fmt.Errorf("id: read random: %w", err)
+	}
+
+	g.mu.Lock()
+	ms := g.now().UnixMilli()
+	switch {
+	case ms > g.lastMS:
+		g.lastMS = ms
+		g.counter = binary.BigEndian.Uint16(id[6:8]) & counterSeedMask
+	case g.counter < maxCounter:
+		g.counter++
+	default:
+		g.lastMS++
+		g.counter = binary.BigEndian.Uint16(id[6:8]) & counterSeedMask
+	}
+	ms, counter := g.lastMS, g.counter
+	g.mu.Unlock()
+
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+	binary.BigEndian.PutUint16(id[6:8], version<<counterBits|counter)
+	id[8] = id[8]&0x3f | 0x80 // RFC 9562 variant
+	return id, nil
+}
+
+// Time returns the millisecond the ID was generated in
+func (id ID) Time() time.Time {
+	ms := int64(id[0])<<40 | int64(id[1])<<32 | int64(id[2])<<24 |
+		int64(id[3])<<16 | int64(id[4])<<8 | int64(id[5])
+	return time.UnixMilli(ms).UTC()
+}
+
+// Version returns the UUID version, 7 for IDs from this package
+func (id ID) Version() int {
+	return int(id[6] >> 4)
+}
+
+// IsZero reports whether id is Nil
+func (id ID) IsZero() bool {
+	return id == Nil
+}
+
+// String returns the canonical hyphenated UUID form
+func (id ID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], id[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], id[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], id[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], id[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], id[10:])
+	return string(b[:])
+}
+
+// Typed returns prefix, an underscore and the Base62 form. An empty prefix
+// returns the Base62 form alone.
+func (id ID) Typed(prefix string) string {
+	if prefix == "" {
+		return id.Base62()
+	}
+	return prefix + "_" + id.Base62()
+}
+
+// MarshalText encodes the canonical form
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText accepts any form Parse does
+func (id *ID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Parse decodes the canonical UUID, Base32 or Base62 form, telling them
+// apart by length
+func Parse(s string) (ID, error) {
+	switch len(s) {
+	case 36:
+		return parseUUID(s)
+	case base32Length:
+		return ParseBase32(s)
+	case base62Length:
+		return ParseBase62(s)
+	}
+	return Nil, ErrInvalid
+}
+
+// ParseTyped decodes a typed ID, requiring prefix
+func ParseTyped(s, prefix string) (ID, error) {
+	got, encoded, ok := strings.Cut(s, "_")
+	if !ok {
+		return Nil, ErrInvalid
+	}
+	if got != prefix {
+		return Nil, ErrPrefixMismatch
+	}
+	return ParseBase62(encoded)
+}
+
+func parseUUID(s string) (ID, error) {
+	var id ID
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return Nil, ErrInvalid
+	}
+	hexDigits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(id[:], []byte(hexDigits)); err != nil {
+		return Nil, ErrInvalid
+	}
+	return id, nil
+}
+
+// Crockford's Base32 alphabet, in ascending ASCII order
+const (
+	base32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base32Length   = 26
+)
+
+// Base32 returns the 26-character Crockford Base32 form
+func (id ID) Base32() string {
+	// 128 bits in 26 five-bit groups leaves two leading zero bits
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+	var b [base32Length]byte
+	for i := base32Length - 1; i >= 0; i-- {
+		b[i] = base32Alphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(b[:])
+}
+
+// ParseBase32 decodes the Crockford Base32 form. It is case-insensitive
+// and reads I and L as 1 and O as 0.
+func ParseBase32(s string) (ID, error) {
+	if len(s) != base32Length {
+		return Nil, ErrInvalid
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := base32Value(s[i])
+		if v < 0 || (i == 0 && v > 7) {
+			return Nil, ErrInvalid
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	var id ID
+	binary.BigEndian.PutUint64(id[0:8], hi)
+	binary.BigEndian.PutUint64(id[8:16], lo)
+	return id, nil
+}
+
+func base32Value(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch c {
+	case 'I', 'L':
+		return 1
+	case 'O':
+		return 0
+	}
+	return strings.IndexByte(base32Alphabet, c)
+}
+
+// Base62 digits, in ascending ASCII order so fixed-width strings sort like
+// the IDs they encode
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	base62Length   = 22
+)
+
+var (
+	sixtyTwo = big.NewInt(62)
+	maxID    = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+// Base62 returns the 22-character Base62 form, zero-padded so it sorts
+func (id ID) Base62() string {
+	n := new(big.Int).SetBytes(id[:])
+	var b [base62Length]byte
+	rem := new(big.Int)
+	for i := base62Length - 1; i >= 0; i-- {
+		n.QuoRem(n, sixtyTwo, rem)
+		b[i] = base62Alphabet[rem.Int64()]
+	}
+	return string(b[:])
+}
+
+// ParseBase62 decodes the Base62 form
+func ParseBase62(s string) (ID, error) {
+	if len(s) != base62Length {
+		return Nil, ErrInvalid
+	}
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(base62Alphabet, s[i])
+		if v < 0 {
+			return Nil, ErrInvalid
+		}
+		n.Mul(n, sixtyTwo)
+		n.Add(n, big.NewInt(int64(v)))
+	}
+	if n.Cmp(maxID) >= 0 {
+		return Nil, ErrInvalid
+	}
+	var id ID
+	n.FillBytes(id[:])
+	return id, nil
+}
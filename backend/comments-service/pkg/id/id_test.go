@@ -0,0 +1,134 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGenerator_Monotonic(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := now
+	g := NewGenerator(func() time.Time { return clock }, nil)
+
+	var ids []ID
+	for i := 0; i < 10000; i++ {
+		// The clock stalls, then jumps back a second halfway through
+		if i == 5000 {
+			clock = now.Add(-time.Second)
+		}
+		id, err := g.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if bytes.Compare(ids[i-1][:], ids[i][:]) >= 0 {
+			t.Fatalf("id %d is not greater than id %d", i, i-1)
+		}
+		if ids[i-1].String() >= ids[i].String() || ids[i-1].Base32() >= ids[i].Base32() || ids[i-1].Base62() >= ids[i].Base62() {
+			t.Fatalf("encodings of id %d do not sort after id %d", i, i-1)
+		}
+	}
+	// 10000 IDs overflow the counter of one millisecond a few times
+	if last := ids[len(ids)-1].Time(); !last.After(now) || last.Sub(now) > 10*time.Millisecond {
+		t.Errorf("expected the timestamp to borrow a few milliseconds, got %s", last)
+	}
+}
+
+func TestID_Layout(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 123e6, time.UTC)
+	g := NewGenerator(func() time.Time { return at }, nil)
+	id, err := g.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !id.Time().Equal(at) {
+		t.Errorf("expected time %s, got %s", at, id.Time())
+	}
+	parsed, err := uuid.Parse(id.String())
+	if err != nil {
+		t.Fatalf("expected a valid UUID, got %v", err)
+	}
+	if parsed.Version() != 7 || parsed.Variant() != uuid.RFC4122 || id.Version() != 7 {
+		t.Errorf("expected an RFC 9562 version 7 UUID, got version %d variant %s", parsed.Version(), parsed.Variant())
+	}
+}
+
+func TestParse(t *testing.T) {
+	id := MustNew()
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"uuid", id.String()},
+		{"upper uuid", strings.ToUpper(id.String())},
+		{"base32", id.Base32()},
+		{"lower base32", strings.ToLower(id.Base32())},
+		{"base62", id.Base62()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil || got != id {
+				t.Errorf("expected %s, got %s (%v)", id, got, err)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "not-an-id", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "zzzzzzzzzzzzzzzzzzzzzz", "0190a6f4-3b2c-7def-8123-45678-abcdef", strings.Repeat("!", 22)} {
+		if _, err := Parse(bad); !errors.Is(err, ErrInvalid) {
+			t.Errorf("expected ErrInvalid for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestTyped(t *testing.T) {
+	id := MustNew()
+	s := id.Typed(PrefixComment)
+	if !strings.HasPrefix(s, "cmt_") || len(s) != len("cmt_")+22 {
+		t.Errorf("unexpected typed id %q", s)
+	}
+
+	got, err := ParseTyped(s, PrefixComment)
+	if err != nil || got != id {
+		t.Errorf("expected %s, got %s (%v)", id, got, err)
+	}
+	if _, err := ParseTyped(s, PrefixAPIKey); !errors.Is(err, ErrPrefixMismatch) {
+		t.Errorf("expected ErrPrefixMismatch, got %v", err)
+	}
+	if _, err := ParseTyped(id.Base62(), PrefixComment); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected ErrInvalid without a prefix, got %v", err)
+	}
+}
+
+func TestGenerator_RandomFailure(t *testing.T) {
+	g := NewGenerator(nil, bytes.NewReader(nil))
+	if _, err := g.New(); err == nil {
+		t.Error("expected an error when entropy runs out")
+	}
+}
+
+func TestID_TextRoundTrip(t *testing.T) {
+	ids := []ID{MustNew(), MustNew(), MustNew()}
+	texts := make([]string, len(ids))
+	for i, id := range ids {
+		b, _ := id.MarshalText()
+		texts[i] = string(b)
+	}
+	if !sort.StringsAreSorted(texts) {
+		t.Errorf("expected canonical forms in creation order, got %v", texts)
+	}
+	var back ID
+	if err := back.UnmarshalText([]byte(texts[1])); err != nil || back != ids[1] {
+		t.Errorf("expected %s, got %s (%v)", ids[1], back, err)
+	}
+}
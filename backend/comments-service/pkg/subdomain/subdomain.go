@@ -0,0 +1,264 @@
+// Package subdomain decides which names tenants may register as their
+// subdomain. A name must be a single RFC 1123 label; internationalized
+// names are accepted and stored as punycode. Names that imitate another
+// script, reserved names and blocked words are refused, the last two
+// compared after homoglyphs and accents are folded so "аpi" and "ädmin"
+// are caught too.
+package subdomain
+
+import (
+	"bufio"
+	_ "embed"
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+
+	"github.com/ayushvyasgit/comments-service/pkg/errors"
+	"github.com/ayushvyasgit/comments-service/pkg/text"
+)
+
+// Length bounds in bytes of the ASCII form: the tenants_subdomain_length
+// constraint and the RFC 1035 label limit
+const (
+	MinLength = 3
+	MaxLength = 63
+)
+
+// Reason says why a name was refused
+type Reason string
+
+const (
+	ReasonEmpty             Reason = "empty"
+	ReasonTooShort          Reason = "too_short"
+	ReasonTooLong           Reason = "too_long"
+	ReasonInvalidCharacters Reason = "invalid_characters"
+	ReasonHyphen            Reason = "hyphen"
+	ReasonConfusable        Reason = "confusable"
+	ReasonReserved          Reason = "reserved"
+	ReasonBlocked           Reason = "blocked"
+	ReasonTaken             Reason = "taken"
+)
+
+var messages = map[Reason]string{
+	ReasonEmpty:             "is required",
+	ReasonTooShort:          "must be at least 3 characters",
+	ReasonTooLong:           "must be at most 63 characters",
+	ReasonInvalidCharacters: "may only contain letters, digits and hyphens",
+	ReasonHyphen:            "cannot start or end with a hyphen, or have two in the third and fourth place",
+	ReasonConfusable:        "mixes lookalike letters from different scripts",
+	ReasonReserved:          "is reserved",
+	ReasonBlocked:           "contains a word that is not allowed",
+	ReasonTaken:             "is already taken",
+}
+
+// Error is a refused name
+type Error struct {
+	Reason    Reason
+	Subdomain string
+}
+
+func (e *Error) Error() string {
+	return "subdomain: " + messages[e.Reason]
+}
+
+// Rule returns the validation rule the reason is reported under:
+// "subdomain" for malformed names, and a rule of its own for each policy
+func (e *Error) Rule() string {
+	switch e.Reason {
+	case ReasonConfusable, ReasonReserved, ReasonBlocked, ReasonTaken:
+		return "subdomain_" + string(e.Reason)
+	}
+	return "subdomain"
+}
+
+// FieldError describes the refusal as a violation of the field at path
+func (e *Error) FieldError(path string) errors.FieldError {
+	params := map[string]any{"reason": string(e.Reason)}
+	switch e.Reason {
+	case ReasonTooShort:
+		params["min"] = MinLength
+	case ReasonTooLong:
+		params["max"] = MaxLength
+	}
+	return errors.FieldError{Path: path, Rule: e.Rule(), Params: params, Message: messages[e.Reason]}
+}
+
+// ReasonOf returns the reason err refused a name, or "" when err is not an
+// *Error
+func ReasonOf(err error) Reason {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e.Reason
+	}
+	return ""
+}
+
+// Name is an accepted subdomain
+type Name struct {
+	// ASCII is the lowercase form stored in tenants.subdomain, punycode for
+	// internationalized names
+	ASCII string
+	// Unicode is the form shown to users
+	Unicode string
+}
+
+//go:embed reserved.txt
+var reservedList string
+
+//go:embed blocked.txt
+var blockedList string
+
+// DefaultReserved returns the names no tenant may register
+func DefaultReserved() []string {
+	return readList(reservedList)
+}
+
+// DefaultBlocked returns the words no subdomain may contain
+func DefaultBlocked() []string {
+	return readList(blockedList)
+}
+
+func readList(list string) []string {
+	var entries []string
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
+
+// Policy checks names against a reserved list and a blocked word list
+type Policy struct {
+	reserved map[string]struct{}
+	blocked  map[string]struct{}
+}
+
+// NewPolicy creates a policy refusing the reserved names and any name
+// containing a blocked word as one of its hyphen-separated parts
+func NewPolicy(reserved, blocked []string) *Policy {
+	return &Policy{reserved: skeletons(reserved), blocked: skeletons(blocked)}
+}
+
+// DefaultPolicy uses DefaultReserved and DefaultBlocked
+func DefaultPolicy() *Policy {
+	return NewPolicy(DefaultReserved(), DefaultBlocked())
+}
+
+func skeletons(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		set[text.Fold(w)] = struct{}{}
+	}
+	return set
+}
+
+// Check validates name and returns its ASCII and Unicode forms. Case and
+// surrounding whitespace are ignored; punycode and Unicode input are
+// equivalent.
+func (p *Policy) Check(name string) (*Name, error) {
+	n, err := Parse(name)
+	if err != nil {
+		return nil, err
+	}
+	if text.IsConfusable(n.Unicode) {
+		return nil, &Error{Reason: ReasonConfusable, Subdomain: name}
+	}
+
+	skeleton := text.Fold(n.Unicode)
+	if contains(p.reserved, skeleton) || contains(p.reserved, strings.ReplaceAll(skeleton, "-", "")) {
+		return nil, &Error{Reason: ReasonReserved, Subdomain: name}
+	}
+	for _, part := range append(strings.Split(skeleton, "-"), strings.ReplaceAll(skeleton, "-", "")) {
+		if contains(p.blocked, part) {
+			return nil, &Error{Reason: ReasonBlocked, Subdomain: name}
+		}
+	}
+	return n, nil
+}
+
+func contains(set map[string]struct{}, word string) bool {
+	_, ok := set[word]
+	return ok
+}
+
+// Parse applies only the RFC 1123 label rules, to the ASCII form of name
+func Parse(name string) (*Name, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil, &Error{Reason: ReasonEmpty, Subdomain: name}
+	}
+	// Two hyphens in the third and fourth place are reserved for
+	// encodings such as punycode's xn--
+	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") ||
+		len(name) >= 4 && name[2:4] == "--" && !strings.HasPrefix(name, "xn--") {
+		return nil, &Error{Reason: ReasonHyphen, Subdomain: name}
+	}
+	ascii, err := idna.Lookup.ToASCII(name)
+	if err != nil || strings.Contains(ascii, ".") {
+		return nil, &Error{Reason: ReasonInvalidCharacters, Subdomain: name}
+	}
+	switch {
+	case len(ascii) < MinLength:
+		return nil, &Error{Reason: ReasonTooShort, Subdomain: name}
+	case len(ascii) > MaxLength:
+		return nil, &Error{Reason: ReasonTooLong, Subdomain: name}
+	}
+	display, err := idna.Registration.ToUnicode(ascii)
+	if err != nil || strings.IndexFunc(display, notLDH) >= 0 {
+		return nil, &Error{Reason: ReasonInvalidCharacters, Subdomain: name}
+	}
+	return &Name{ASCII: ascii, Unicode: display}, nil
+}
+
+// notLDH reports runes outside the letters, digits and hyphen that
+// RFC 1123 and, for other scripts, RFC 5892 allow. The idna tables accept
+// symbols such as emoji.
+func notLDH(r rune) bool {
+	return r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.In(r, unicode.Mn, unicode.Mc)
+}
+
+// suggestionSuffixes are appended to a taken name, before numbered
+// variants are tried
+var suggestionSuffixes = []string{"hq", "app", "team", "community", "online"}
+
+// Suggestions returns up to limit alternatives to n, in order of
+// preference, that pass the policy. Whether they are free is up to the
+// caller.
+func (p *Policy) Suggestions(n *Name, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+	var names []string
+	seen := map[string]bool{n.ASCII: true}
+	try := func(suffix string) bool {
+		base := n.Unicode
+		// Shorten ASCII names to make room; punycode lengths are not
+		// worth predicting
+		if base == n.ASCII && len(base)+len(suffix) > MaxLength {
+			base = strings.TrimRight(base[:MaxLength-len(suffix)], "-")
+		}
+		if c, err := p.Check(base + suffix); err == nil && !seen[c.ASCII] {
+			seen[c.ASCII] = true
+			names = append(names, c.ASCII)
+		}
+		return len(names) >= limit
+	}
+
+	for _, suffix := range suggestionSuffixes {
+		if try("-" + suffix) {
+			return names
+		}
+	}
+	for i := 2; i < 100; i++ {
+		if try(strconv.Itoa(i)) {
+			return names
+		}
+	}
+	return names
+}
@@ -0,0 +1,124 @@
+package subdomain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	p := DefaultPolicy()
+	tests := []struct {
+		name    string
+		in      string
+		ascii   string
+		unicode string
+		reason  Reason
+	}{
+		{name: "simple", in: "acme", ascii: "acme", unicode: "acme"},
+		{name: "case and space", in: "  Acme-Corp ", ascii: "acme-corp", unicode: "acme-corp"},
+		{name: "digits first", in: "3m-team", ascii: "3m-team", unicode: "3m-team"},
+		{name: "idn", in: "bücher", ascii: "xn--bcher-kva", unicode: "bücher"},
+		{name: "punycode", in: "XN--BCHER-KVA", ascii: "xn--bcher-kva", unicode: "bücher"},
+		{name: "cyrillic", in: "привет", ascii: "xn--b1agh1afp", unicode: "привет"},
+		{name: "63 characters", in: strings.Repeat("a", 63), ascii: strings.Repeat("a", 63), unicode: strings.Repeat("a", 63)},
+		{name: "blocked word inside another", in: "analytics-hub", ascii: "analytics-hub", unicode: "analytics-hub"},
+
+		{name: "empty", in: " ", reason: ReasonEmpty},
+		{name: "too short", in: "ab", reason: ReasonTooShort},
+		{name: "too long", in: strings.Repeat("a", 64), reason: ReasonTooLong},
+		{name: "idn too long", in: strings.Repeat("bücher", 10), reason: ReasonTooLong},
+		{name: "underscore", in: "acme_corp", reason: ReasonInvalidCharacters},
+		{name: "dot", in: "acme.corp", reason: ReasonInvalidCharacters},
+		{name: "ideographic dot", in: "acme。corp", reason: ReasonInvalidCharacters},
+		{name: "emoji", in: "acme💥", reason: ReasonInvalidCharacters},
+		{name: "bad punycode", in: "xn--zz-", reason: ReasonHyphen},
+		{name: "undecodable punycode", in: "xn--a", reason: ReasonInvalidCharacters},
+		{name: "leading hyphen", in: "-acme", reason: ReasonHyphen},
+		{name: "trailing hyphen", in: "acme-", reason: ReasonHyphen},
+		{name: "reserved hyphens", in: "ab--cd", reason: ReasonHyphen},
+		{name: "mixed scripts", in: "pаypal", reason: ReasonConfusable},
+		{name: "whole-script lookalike", in: "аррӏе", reason: ReasonConfusable},
+		{name: "reserved", in: "www", reason: ReasonReserved},
+		{name: "reserved with accent", in: "ädmin", reason: ReasonReserved},
+		{name: "reserved with digit lookalike", in: "l0gin", reason: ReasonReserved},
+		{name: "reserved with hyphen", in: "web-mail", reason: ReasonReserved},
+		{name: "blocked part", in: "acme-porn", reason: ReasonBlocked},
+		{name: "blocked fullwidth", in: "ｐｏｒｎ", reason: ReasonBlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := p.Check(tt.in)
+			if tt.reason != "" {
+				if got := ReasonOf(err); got != tt.reason {
+					t.Errorf("expected reason %s, got %q (%v)", tt.reason, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n.ASCII != tt.ascii || n.Unicode != tt.unicode {
+				t.Errorf("expected %s (%s), got %s (%s)", tt.ascii, tt.unicode, n.ASCII, n.Unicode)
+			}
+		})
+	}
+}
+
+func TestPolicy_Configurable(t *testing.T) {
+	p := NewPolicy(append(DefaultReserved(), "acme"), []string{"spam"})
+	if got := ReasonOf(checkErr(p, "acme")); got != ReasonReserved {
+		t.Errorf("expected an added reserved name to be refused, got %q", got)
+	}
+	if got := ReasonOf(checkErr(p, "spam-co")); got != ReasonBlocked {
+		t.Errorf("expected an added blocked word to be refused, got %q", got)
+	}
+	if err := checkErr(p, "porn-free"); err != nil {
+		t.Errorf("expected the default blocked list to be replaced, got %v", err)
+	}
+}
+
+func checkErr(p *Policy, name string) error {
+	_, err := p.Check(name)
+	return err
+}
+
+func TestPolicy_Suggestions(t *testing.T) {
+	p := DefaultPolicy()
+
+	n, _ := p.Check("acme")
+	got := p.Suggestions(n, 7)
+	want := []string{"acme-hq", "acme-app", "acme-team", "acme-community", "acme-online", "acme2", "acme3"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	n, _ = p.Check("bücher")
+	if got := p.Suggestions(n, 1); len(got) != 1 || got[0] != "xn--bcher-hq-65a" {
+		t.Errorf("expected a punycode suggestion, got %v", got)
+	}
+
+	long := strings.Repeat("a", 62) + "b"
+	n, _ = p.Check(long)
+	for _, s := range p.Suggestions(n, 3) {
+		if len(s) > MaxLength {
+			t.Errorf("expected suggestions to fit in a label, got %s", s)
+		}
+	}
+
+	if got := p.Suggestions(n, 0); got != nil {
+		t.Errorf("expected no suggestions, got %v", got)
+	}
+}
+
+func TestError_FieldError(t *testing.T) {
+	_, err := DefaultPolicy().Check("ab")
+	fe := err.(*Error).FieldError("subdomain")
+	if fe.Rule != "subdomain" || fe.Params["reason"] != "too_short" || fe.Params["min"] != MinLength {
+		t.Errorf("unexpected field error %+v", fe)
+	}
+	_, err = DefaultPolicy().Check("admin")
+	if fe := err.(*Error).FieldError("subdomain"); fe.Rule != "subdomain_reserved" {
+		t.Errorf("unexpected field error %+v", fe)
+	}
+}
@@ -0,0 +1,232 @@
+package text
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Grapheme_Cluster_Break property values from UAX #29 that the
+// segmentation rules distinguish
+type breakProperty uint8
+
+const (
+	propOther breakProperty = iota
+	propCR
+	propLF
+	propControl
+	propExtend
+	propZWJ
+	propRegionalIndicator
+	propPrepend
+	propSpacingMark
+	propL
+	propV
+	propT
+	propLV
+	propLVT
+)
+
+// extendExtra lists Grapheme_Extend characters outside Mn and Me: ZWNJ,
+// halfwidth sound marks, emoji skin tones and the tag characters of
+// subdivision flags
+var extendExtra = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x200c, Hi: 0x200c, Stride: 1},
+		{Lo: 0xff9e, Hi: 0xff9f, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f3fb, Hi: 0x1f3ff, Stride: 1},
+		{Lo: 0xe0020, Hi: 0xe007f, Stride: 1},
+	},
+}
+
+// prepend lists the Prepend characters, format characters that attach to
+// what follows them
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x0605, Stride: 1},
+		{Lo: 0x06dd, Hi: 0x06dd, Stride: 1},
+		{Lo: 0x070f, Hi: 0x070f, Stride: 1},
+		{Lo: 0x0890, Hi: 0x0891, Stride: 1},
+		{Lo: 0x08e2, Hi: 0x08e2, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x110bd, Hi: 0x110bd, Stride: 1},
+		{Lo: 0x110cd, Hi: 0x110cd, Stride: 1},
+	},
+}
+
+// extendedPictographic approximates the Extended_Pictographic property:
+// the emoji and pictographic blocks that ZWJ sequences are built from
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a9, Hi: 0x00a9, Stride: 1},
+		{Lo: 0x00ae, Hi: 0x00ae, Stride: 1},
+		{Lo: 0x203c, Hi: 0x203c, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21a9, Hi: 0x21aa, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x2388, Hi: 0x2388, Stride: 1},
+		{Lo: 0x23cf, Hi: 0x23cf, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x23f8, Hi: 0x23fa, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25ab, Stride: 1},
+		{Lo: 0x25b6, Hi: 0x25b6, Stride: 1},
+		{Lo: 0x25c0, Hi: 0x25c0, Stride: 1},
+		{Lo: 0x25fb, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b07, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303d, Hi: 0x303d, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f0ff, Stride: 1},
+		{Lo: 0x1f10d, Hi: 0x1f10f, Stride: 1},
+		{Lo: 0x1f12f, Hi: 0x1f12f, Stride: 1},
+		{Lo: 0x1f16c, Hi: 0x1f171, Stride: 1},
+		{Lo: 0x1f17e, Hi: 0x1f17f, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f1ad, Hi: 0x1f1e5, Stride: 1},
+		{Lo: 0x1f201, Hi: 0x1f20f, Stride: 1},
+		{Lo: 0x1f21a, Hi: 0x1f21a, Stride: 1},
+		{Lo: 0x1f22f, Hi: 0x1f22f, Stride: 1},
+		{Lo: 0x1f232, Hi: 0x1f23a, Stride: 1},
+		{Lo: 0x1f23c, Hi: 0x1f23f, Stride: 1},
+		{Lo: 0x1f249, Hi: 0x1f3fa, Stride: 1},
+		{Lo: 0x1f400, Hi: 0x1f53d, Stride: 1},
+		{Lo: 0x1f546, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f774, Hi: 0x1f77f, Stride: 1},
+		{Lo: 0x1f7d5, Hi: 0x1f7ff, Stride: 1},
+		{Lo: 0x1f80c, Hi: 0x1f80f, Stride: 1},
+		{Lo: 0x1f848, Hi: 0x1f84f, Stride: 1},
+		{Lo: 0x1f85a, Hi: 0x1f85f, Stride: 1},
+		{Lo: 0x1f888, Hi: 0x1f88f, Stride: 1},
+		{Lo: 0x1f8ae, Hi: 0x1f8ff, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f93a, Stride: 1},
+		{Lo: 0x1f93c, Hi: 0x1f945, Stride: 1},
+		{Lo: 0x1f947, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x1fc00, Hi: 0x1fffd, Stride: 1},
+	},
+}
+
+func propertyOf(r rune) breakProperty {
+	switch {
+	case r == '\r':
+		return propCR
+	case r == '\n':
+		return propLF
+	case r == 0x200d:
+		return propZWJ
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return propRegionalIndicator
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return propLV
+		}
+		return propLVT
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return propL
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return propV
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return propT
+	case unicode.In(r, unicode.Mn, unicode.Me, extendExtra):
+		return propExtend
+	case unicode.Is(prepend, r):
+		return propPrepend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return propControl
+	case unicode.Is(unicode.Mc, r):
+		return propSpacingMark
+	}
+	return propOther
+}
+
+// clusterLen returns the length in bytes of the grapheme cluster s starts
+// with, following the extended grapheme cluster rules of UAX #29
+func clusterLen(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return 0
+	}
+	prev := propertyOf(r)
+	// emoji: the cluster ends in Extended_Pictographic Extend*;
+	// emojiZWJ: it ends in Extended_Pictographic Extend* ZWJ
+	emoji := unicode.Is(extendedPictographic, r)
+	emojiZWJ := false
+	regional := 0
+	if prev == propRegionalIndicator {
+		regional = 1
+	}
+
+	pos := n
+	for pos < len(s) {
+		r, n := utf8.DecodeRuneInString(s[pos:])
+		next := propertyOf(r)
+		pictographic := unicode.Is(extendedPictographic, r)
+		if isBoundary(prev, next, pictographic && emojiZWJ, regional) {
+			break
+		}
+
+		switch {
+		case pictographic:
+			emoji, emojiZWJ = true, false
+		case next == propExtend:
+			emojiZWJ = false
+		case next == propZWJ:
+			emoji, emojiZWJ = false, emoji
+		default:
+			emoji, emojiZWJ = false, false
+		}
+		if next == propRegionalIndicator {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = next
+		pos += n
+	}
+	return pos
+}
+
+// isBoundary applies rules GB3 to GB999. joinsEmoji reports GB11, and
+// regional counts the regional indicators just before the candidate
+// boundary for GB12 and GB13.
+func isBoundary(prev, next breakProperty, joinsEmoji bool, regional int) bool {
+	switch {
+	case prev == propCR && next == propLF:
+		return false
+	case prev == propCR, prev == propLF, prev == propControl:
+		return true
+	case next == propCR, next == propLF, next == propControl:
+		return true
+	case prev == propL && (next == propL || next == propV || next == propLV || next == propLVT):
+		return false
+	case (prev == propLV || prev == propV) && (next == propV || next == propT):
+		return false
+	case (prev == propLVT || prev == propT) && next == propT:
+		return false
+	case next == propExtend, next == propZWJ, next == propSpacingMark:
+		return false
+	case prev == propPrepend:
+		return false
+	case joinsEmoji:
+		return false
+	case prev == propRegionalIndicator && next == propRegionalIndicator:
+		return regional%2 == 0
+	}
+	return true
+}
@@ -0,0 +1,211 @@
+// Package text provides Unicode-aware helpers for user-written text.
+//
+// Lengths count grapheme clusters, the characters a reader sees: "é"
+// written as e plus a combining accent, a flag and a family emoji joined by
+// ZWJs are each one character, although they take several runes and many
+// bytes. Truncation never splits a cluster.
+package text
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Length returns the number of grapheme clusters in s
+func Length(s string) int {
+	count := 0
+	for s != "" {
+		s = s[clusterLen(s):]
+		count++
+	}
+	return count
+}
+
+// Graphemes splits s into its grapheme clusters
+func Graphemes(s string) []string {
+	var clusters []string
+	for s != "" {
+		n := clusterLen(s)
+		clusters = append(clusters, s[:n])
+		s = s[n:]
+	}
+	return clusters
+}
+
+// Truncate returns the first max grapheme clusters of s
+func Truncate(s string, max int) string {
+	pos := 0
+	for i := 0; i < max && pos < len(s); i++ {
+		pos += clusterLen(s[pos:])
+	}
+	return s[:pos]
+}
+
+// TruncateWithEllipsis shortens s to at most max grapheme clusters,
+// ending it with ellipsis when anything was cut. A max too small to hold
+// the ellipsis cuts without it.
+func TruncateWithEllipsis(s string, max int, ellipsis string) string {
+	if Length(s) <= max {
+		return s
+	}
+	room := max - Length(ellipsis)
+	if room < 0 {
+		return Truncate(s, max)
+	}
+	return strings.TrimRightFunc(Truncate(s, room), unicode.IsSpace) + ellipsis
+}
+
+// Normalize returns the NFC form of s, so that text which renders the same
+// compares and counts the same
+func Normalize(s string) string {
+	return norm.NFC.String(s)
+}
+
+// invisible lists characters that render as nothing: zero-width spaces,
+// the word joiner, the byte order mark, soft hyphens and the bidi controls
+// that can reorder what a reader sees. ZWJ and ZWNJ are not here; emoji
+// sequences and several scripts depend on them.
+var invisible = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00ad, Hi: 0x00ad, Stride: 1},
+		{Lo: 0x034f, Hi: 0x034f, Stride: 1},
+		{Lo: 0x061c, Hi: 0x061c, Stride: 1},
+		{Lo: 0x17b4, Hi: 0x17b5, Stride: 1},
+		{Lo: 0x180e, Hi: 0x180e, Stride: 1},
+		{Lo: 0x200b, Hi: 0x200b, Stride: 1},
+		{Lo: 0x200e, Hi: 0x200f, Stride: 1},
+		{Lo: 0x202a, Hi: 0x202e, Stride: 1},
+		{Lo: 0x2060, Hi: 0x2064, Stride: 1},
+		{Lo: 0x2066, Hi: 0x206f, Stride: 1},
+		{Lo: 0x3164, Hi: 0x3164, Stride: 1},
+		{Lo: 0xfeff, Hi: 0xfeff, Stride: 1},
+		{Lo: 0xffa0, Hi: 0xffa0, Stride: 1},
+	},
+}
+
+// StripInvisible removes zero-width and bidi control characters
+func StripInvisible(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(invisible, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// CollapseSpace trims s, turns every run of horizontal whitespace into a
+// single space, drops trailing spaces on each line and keeps at most one
+// blank line between paragraphs
+func CollapseSpace(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space, newlines := false, 0
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r == '\n':
+			space = false
+			newlines++
+		case r == '\r':
+		case unicode.IsSpace(r):
+			space = true
+		default:
+			if newlines > 0 {
+				b.WriteString("\n\n"[:min(newlines, 2)])
+			} else if space {
+				b.WriteByte(' ')
+			}
+			space, newlines = false, 0
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Clean prepares user text for storage: NFC normalization, invisible
+// characters removed and surrounding whitespace trimmed
+func Clean(s string) string {
+	return strings.TrimSpace(StripInvisible(Normalize(s)))
+}
+
+// confusables maps lowercase letters from other scripts, and digits, to
+// the Latin letters they are commonly passed off as
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'н': 'h', 'і': 'i',
+	'ј': 'j', 'к': 'k', 'м': 'm', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'с': 'c', 'ѕ': 's',
+	'т': 't', 'у': 'y', 'ԝ': 'w', 'х': 'x', 'ь': 'b', 'ӏ': 'l',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v',
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w', 'γ': 'y',
+	// Latin lookalikes and digits
+	'ı': 'i', 'ȷ': 'j', 'ɑ': 'a', 'ɡ': 'g', 'ɩ': 'i', 'ʀ': 'r',
+	'0': 'o', '1': 'l', '|': 'l',
+}
+
+// Fold reduces s to a skeleton for spam and duplicate checks, never for
+// display. Compatibility forms such as fullwidth and mathematical letters
+// become plain letters, accents and invisible characters are dropped, case
+// is folded, homoglyphs from other scripts become the Latin letters they
+// imitate and whitespace collapses to single spaces. Two strings that look
+// alike fold to the same skeleton.
+func Fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r), unicode.Is(invisible, r), r == 0x200c, r == 0x200d:
+			continue
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		}
+		r = unicode.ToLower(r)
+		if folded, ok := confusables[r]; ok {
+			r = folded
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lookalikeScripts are the scripts whose letters pass for each other
+var lookalikeScripts = []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Greek}
+
+// IsConfusable reports whether s could pass for different text: it mixes
+// Latin, Cyrillic and Greek letters, or it is written entirely in Cyrillic
+// or Greek letters that each look like a Latin one, as "аррӏе" does
+func IsConfusable(s string) bool {
+	var used [3]bool
+	allLookalike := true
+	for _, r := range Normalize(s) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for i, script := range lookalikeScripts {
+			if unicode.Is(script, r) {
+				used[i] = true
+			}
+		}
+		if _, ok := confusables[unicode.ToLower(r)]; !ok {
+			allLookalike = false
+		}
+	}
+
+	scripts := 0
+	for _, u := range used {
+		if u {
+			scripts++
+		}
+	}
+	if scripts > 1 {
+		return true
+	}
+	return !used[0] && scripts == 1 && allLookalike
+}
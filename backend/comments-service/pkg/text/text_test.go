@@ -0,0 +1,146 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"precomposed", "café", []string{"c", "a", "f", "é"}},
+		{"combining accent", "cafe\u0301", []string{"c", "a", "f", "e\u0301"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"skin tone", "👍🏽!", []string{"👍🏽", "!"}},
+		{"zwj family", "👨\u200D👩\u200D👧\u200D👦", []string{"👨\u200D👩\u200D👧\u200D👦"}},
+		{"variation selector", "❤\uFE0F", []string{"❤\uFE0F"}},
+		{"flags", "🇩🇪🇫🇷🇯", []string{"🇩🇪", "🇫🇷", "🇯"}},
+		{"subdivision flag", "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", []string{"🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F"}},
+		{"hangul jamo", "각한", []string{"각", "한"}},
+		{"devanagari", "नमस्ते", []string{"न", "म", "स्", "ते"}},
+		{"zwj without emoji", "a\u200Db", []string{"a\u200D", "b"}},
+		{"control", "a\tb", []string{"a", "\t", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Graphemes(tt.in)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if n := Length(tt.in); n != len(tt.want) {
+				t.Errorf("expected length %d, got %d", len(tt.want), n)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		max      int
+		ellipsis string
+		want     string
+	}{
+		{"fits", "héllo", 5, "…", "héllo"},
+		{"cuts by character", "héllo wörld", 8, "…", "héllo w…"},
+		{"trims before the ellipsis", "héllo wörld", 9, "...", "héllo..."},
+		{"keeps emoji whole", "hi 👨\u200D👩\u200D👧 there", 4, "", "hi 👨\u200D👩\u200D👧"},
+		{"keeps accents", "cafe\u0301s", 4, "", "cafe\u0301"},
+		{"too short for the ellipsis", "hello", 2, "...", "he"},
+		{"zero", "hello", 0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateWithEllipsis(tt.in, tt.max, tt.ellipsis); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"normalizes to NFC", "cafe\u0301", "café"},
+		{"strips zero-width", "\u200Bfree\u2060 mo\uFEFFney\u00AD", "free money"},
+		{"strips bidi overrides", "abc\u202Etxt.exe", "abctxt.exe"},
+		{"keeps zwj sequences", "👩\u200D💻", "👩\u200D💻"},
+		{"trims", " \u200B hello \n", "hello"},
+		{"only invisible", "\u200B\u200B", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clean(tt.in); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCollapseSpace(t *testing.T) {
+	in := "  hello \t  world  \r\n\n\n\nsecond\u3000 paragraph \n"
+	want := "hello world\n\nsecond paragraph"
+	if got := CollapseSpace(in); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"case", "FREE Money", "free money"},
+		{"cyrillic homoglyphs", "раураl", "paypal"},
+		{"greek homoglyphs", "Αmazοn", "amazon"},
+		{"fullwidth", "ｆｒｅｅ", "free"},
+		{"mathematical", "𝐟𝐫𝐞𝐞", "free"},
+		{"accents", "fréé mönéy", "free money"},
+		{"digits", "fr33 m0ney", "fr33 money"},
+		{"invisible", "fr\u200Be\u200De", "free"},
+		{"whitespace", "  free \t\n money  ", "free money"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fold(tt.in); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsConfusable(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"paypal", false},
+		{"bücher", false},
+		{"привет", false},
+		{"αθήνα", false},
+		{"東京", false},
+		{"pаypal", true},
+		{"аррӏе", true},
+		{"ΑΡΙ", true},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsConfusable(tt.in); got != tt.want {
+			t.Errorf("expected IsConfusable(%q) = %v, got %v", tt.in, tt.want, got)
+		}
+	}
+}
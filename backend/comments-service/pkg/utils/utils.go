@@ -2,26 +2,28 @@
 package utils
 
 import (
-	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"strings"
 	"time"
+
+	"github.com/ayushvyasgit/comments-service/pkg/email"
+	"github.com/ayushvyasgit/comments-service/pkg/id"
+	"github.com/ayushvyasgit/comments-service/pkg/text"
 )
 
-// GenerateID generates a random hex ID
+// GenerateID generates a time-ordered typed ID such as cmt_0Gkh3lsZQJ8BmS4VMXPmd1
+//
+// Deprecated: use package id, which reports entropy failures instead of
+// panicking and exposes the UUID form stored in the database.
 func GenerateID(prefix string) string {
-	bytes := make([]byte, 16)
-	rand.Read(bytes)
-	id := hex.EncodeToString(bytes)
-	if prefix != "" {
-		return fmt.Sprintf("%s_%s", prefix, id)
-	}
-	return id
+	return id.MustNew().Typed(prefix)
 }
 
 // HashString creates a SHA-256 hash of a string
+//
+// Deprecated: API keys are hashed by package apikey, under a server-side
+// pepper; an unkeyed hash lets anyone holding it check guesses offline.
 func HashString(s string) string {
 	hash := sha256.Sum256([]byte(s))
 	return hex.EncodeToString(hash[:])
@@ -86,63 +88,46 @@ func ContainsInt(slice []int, item int) bool {
 	return false
 }
 
-// TruncateString truncates a string to a maximum length
+// TruncateString truncates a string to at most maxLen characters, ending it
+// with "..." when it was cut. Characters are grapheme clusters, so emoji and
+// accented letters are never split.
 func TruncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen < 3 {
-		return s[:maxLen]
-	}
-	return s[:maxLen-3] + "..."
+	return text.TruncateWithEllipsis(s, maxLen, "...")
 }
 
 // SanitizeSubdomain ensures a subdomain is valid (lowercase, alphanumeric, hyphens)
+//
+// Deprecated: use package subdomain, which also enforces label length,
+// reserved names and internationalized names instead of dropping characters.
 func SanitizeSubdomain(subdomain string) string {
 	subdomain = strings.ToLower(subdomain)
 	subdomain = strings.TrimSpace(subdomain)
 	
 	// Remove invalid characters
-	result := ""
+	var result strings.Builder
+	result.Grow(len(subdomain))
 	for _, char := range subdomain {
 		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-			result += string(char)
+			result.WriteRune(char)
 		}
 	}
 	
 	// Remove leading/trailing hyphens
-	result = strings.Trim(result, "-")
-	
-	return result
+	return strings.Trim(result.String(), "-")
 }
 
-// ValidateEmail performs basic email validation
-func ValidateEmail(email string) bool {
-	if email == "" {
-		return false
-	}
-	
-	parts := strings.Split(email, "@")
-	if len(parts) != 2 {
-		return false
-	}
-	
-	localPart := parts[0]
-	domainPart := parts[1]
-	
-	if localPart == "" || domainPart == "" {
-		return false
-	}
-	
-	// Check domain has at least one dot
-	if !strings.Contains(domainPart, ".") {
-		return false
-	}
-	
-	return true
+// ValidateEmail reports whether email is a syntactically valid address
+//
+// Deprecated: use package email, which also reports why an address was
+// rejected and applies tenant domain policies.
+func ValidateEmail(address string) bool {
+	_, err := email.Parse(address)
+	return err == nil
 }
 
 // Paginate calculates pagination offset
+//
+// Deprecated: list endpoints page with keyset cursors; use package cursor.
 func Paginate(page, limit int) (offset int, actualLimit int) {
 	if page < 1 {
 		page = 1
@@ -159,6 +144,8 @@ func Paginate(page, limit int) (offset int, actualLimit int) {
 }
 
 // CalculateTotalPages calculates total pages for pagination
+//
+// Deprecated: keyset pagination does not count pages; use package cursor.
 func CalculateTotalPages(total, limit int) int {
 	if limit == 0 {
 		return 0
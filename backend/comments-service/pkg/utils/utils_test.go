@@ -191,6 +191,9 @@ func TestTruncateString(t *testing.T) {
 		{"truncate with ellipsis", "hello world", 8, "hello..."},
 		{"exact length", "hello", 5, "hello"},
 		{"very short maxLen", "hello", 2, "he"},
+		{"multi-byte characters", "héllo wörld", 9, "héllo..."},
+		{"emoji", "👍🏽👍🏽👍🏽👍🏽", 3, "..."},
+		{"emoji kept whole", "ok 👨\u200D👩\u200D👧 thanks", 7, "ok 👨\u200D👩\u200D👧..."},
 	}
 
 	for _, tt := range tests {
@@ -241,6 +244,9 @@ func TestValidateEmail(t *testing.T) {
 		{"no local part", "@example.com", false},
 		{"multiple @", "user@@example.com", false},
 		{"no TLD", "user@example", false},
+		{"quoted local part", `"john doe"@example.com`, true},
+		{"internationalized domain", "user@bücher.de", true},
+		{"double dot", "john..doe@example.com", false},
 		{"empty string", "", false},
 	}
 